@@ -12,6 +12,7 @@ from ..builtins.core.simple_parameters import UNTYPED_LIST_TYPE
 from ..util.message import i18n as _
 from ..util.message import UserMessage
 from ..util.result import Problem
+from ..util.suggest import closest_match
 
 
 def assign_types_to_node(  # pylint:disable=too-many-branches
@@ -70,16 +71,33 @@ def assign_types_to_node(  # pylint:disable=too-many-branches
         else:
             # Otherwise, it's a parameter / construct node.  These
             #   have to have types.
-            node.add_problem(
-                Problem.as_validation(
-                    node.node_id.source,
-                    UserMessage(
-                        _("node has unknown type {type}"),
-                        node=repr(node),
-                        type=node.type_id,
-                    ),
-                )
+            suggest = closest_match(
+                node.type_id,
+                (h.type().type_id() for h in tree.handlers.all()),
             )
+            if suggest:
+                node.add_problem(
+                    Problem.as_validation(
+                        node.node_id.source,
+                        UserMessage(
+                            _("node has unknown type {type}; did you mean '{suggest}'?"),
+                            node=repr(node),
+                            type=node.type_id,
+                            suggest=suggest,
+                        ),
+                    )
+                )
+            else:
+                node.add_problem(
+                    Problem.as_validation(
+                        node.node_id.source,
+                        UserMessage(
+                            _("node has unknown type {type}"),
+                            node=repr(node),
+                            type=node.type_id,
+                        ),
+                    )
+                )
     else:
         tree.mark_referenced(handler)
         handler_type = handler.type()
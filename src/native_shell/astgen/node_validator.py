@@ -23,6 +23,7 @@ from ..defs.node_type import (
 )
 from ..util.message import i18n as _
 from ..util.result import Problem
+from ..util.suggest import closest_match
 
 
 def validate_node(node: AbcParsedNode) -> None:
@@ -111,6 +112,8 @@ _SIMPLE_TYPE: Mapping[BasicTypeId, Callable[[SimpleParameter], bool]] = {
     "integer": lambda p: isinstance(p, int),
     "boolean": lambda p: isinstance(p, bool),
     "reference": _is_reference_type,
+    "duration": lambda p: isinstance(p, int) and not isinstance(p, bool),
+    "byte-size": lambda p: isinstance(p, int) and not isinstance(p, bool) and p >= 0,
 }
 
 
@@ -205,13 +208,26 @@ def check_parameter_node(node: ParsedParameterNode, node_type: AbcType) -> None:
         param = expected_parameters.get(str(key))
         if not param:
             # Attach the problem to the child.
-            node.add_problem(
-                Problem.as_validation(
-                    child.node_id.source,
-                    _("Node in unknown parent parameter {key}"),
-                    key=key,
+            suggest = closest_match(str(key), (p.key() for p in node_type.parameters()))
+            if suggest:
+                node.add_problem(
+                    Problem.as_validation(
+                        child.node_id.source,
+                        _("Node in unknown parent parameter {key}; did you mean '{suggest}'?"),
+                        key=key,
+                        suggest=suggest,
+                    )
+                )
+            else:
+                node.add_problem(
+                    Problem.as_validation(
+                        child.node_id.source,
+                        _("Node in unknown parent parameter {key}; {typ} allows {keys}"),
+                        key=key,
+                        typ=node_type.type_id(),
+                        keys=", ".join(sorted(f"'{p.key()}'" for p in node_type.parameters())),
+                    )
                 )
-            )
         else:
             # Mark the key as found.
             del expected_parameters[str(key)]
@@ -25,6 +25,8 @@ from .simple_parameters import (
     BOOLEAN_LIST_TYPE,
     STRING_LIST_TYPE,
     REFERENCE_LIST_TYPE,
+    DURATION_LIST_TYPE,
+    BYTE_SIZE_LIST_TYPE,
     UNTYPED_LIST_TYPE,
     BASIC_LIST_TYPE,
 )
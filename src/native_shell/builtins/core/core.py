@@ -20,6 +20,7 @@ from .simple_field import (
     OS_FILE_FIELD_HANDLER,
 )
 from .sequential import SEQUENTIAL
+from .step import STEP
 from ...defs.add_ins import AddIn
 
 
@@ -30,6 +31,7 @@ CORE = AddIn(
     type_handlers=(
         ECHO,
         SEQUENTIAL,
+        STEP,
         ERROR_FIELD_HANDLER,
         INT_FIELD_HANDLER,
         INT8_FIELD_HANDLER,
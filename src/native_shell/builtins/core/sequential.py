@@ -3,6 +3,7 @@
 from typing import Iterable, Sequence, List, Union, Optional
 from .simple_field import ERROR_FIELD_TYPE
 from .consts import ERROR_FIELD_KEY
+from .step import get_step_policy, STEP_POLICY_ABORT, STEP_POLICY_CONTINUE
 from ...defs.add_ins import AddInTypeHandler, GeneratedCode, CodeReference, CodeTemplate
 from ...defs.node_type import AbcType, ConstructType, BOOLEAN_TYPE
 from ...defs.syntax_tree import SyntaxNode, SyntaxParameter
@@ -41,11 +42,23 @@ SEQUENTIAL_REQUIRE_ALL_SUCCESS_KEY = "require all success"
 SEQUENTIAL_REQUIRE_ALL_SUCCESS = create_explicit_type_parameter(
     key=SEQUENTIAL_REQUIRE_ALL_SUCCESS_KEY,
     title=_("require all success"),
-    description=_("if true, require all commands run to succeed"),
+    description=_(
+        "if true, require all commands run to succeed; the remaining commands are "
+        "skipped after the first command fails.  A core.step command can set its "
+        "own policy with 'on failure'"
+    ),
     required=False,
     type_val=BOOLEAN_TYPE,
 )
 
+SEQUENTIAL_FINALLY_KEY = "finally"
+SEQUENTIAL_FINALLY = create_delayed_list_type_parameter(
+    key=SEQUENTIAL_FINALLY_KEY,
+    title=_("finally"),
+    description=_("commands to run after the run commands, even if they failed"),
+    required=False,
+)
+
 SEQUENTIAL_ERROR_FUNCTION_TYPE = ConstructType(
     source=("core", "sequence", "error-function"),
     type_id="core.sequence.error-function",
@@ -77,14 +90,22 @@ SEQUENTIAL_TYPE = ConstructType(
     type_id="core.run",
     title=_("run"),
     description=_("run commands in sequence"),
-    parameters=(SEQUENTIAL_RUN, SEQUENTIAL_REQUIRE_ALL_SUCCESS, SEQUENTIAL_ON_ERROR),
+    parameters=(
+        SEQUENTIAL_RUN,
+        SEQUENTIAL_REQUIRE_ALL_SUCCESS,
+        SEQUENTIAL_ON_ERROR,
+        SEQUENTIAL_FINALLY,
+    ),
     fields=(SEQUENTIAL_ERROR,),
 )
 
 
 class SequentialCommand(AddInTypeHandler):
     """Run other commands in a sequence.  A common error handler is executed if any
-    of the commands' err field is nil after it completes."""
+    of the commands' err field is not nil after it completes.  If all commands are
+    required to succeed, then the sequence stops at the first failed command;
+    a core.step command may instead abort or continue on its own terms.
+    The 'finally' commands always run at the end."""
 
     def type(self) -> AbcType:
         """The type representation for this handler."""
@@ -122,26 +143,29 @@ class SequentialCommand(AddInTypeHandler):
             return res.build(())
 
         on_err_call = _gen_error_func(node, ret)
-
-        calls: List[SyntaxParameter] = []
-        if isinstance(run_code_node.node_type(), ConstructType):
-            # Type checking shouldn't make this a thing, but here it is.
-            calls.append(run_code_node)
-        else:
-            # Should be a list type.
-            calls.extend(get_ordered_children(run_code_node))
+        require_all = node.values().get(SEQUENTIAL_REQUIRE_ALL_SUCCESS_KEY) is True
+        err_var = mk_var_name(err_field.node_id())
 
         run_code: List[Union[CodeReference, str]] = []
-        for call in calls:
+        open_blocks = 0
+        prev_err: Optional[SyntaxNode] = None
+        prev_abort = False
+        for call in _get_calls(run_code_node):
             if not isinstance(call, SyntaxNode):
                 continue
+            if prev_abort and prev_err:
+                # The remaining commands only run if the previous one succeeded.
+                run_code.extend(
+                    ("\nif ", mk_param_code_ref(prev_err, "get_field_value"), " == nil {\n")
+                )
+                open_blocks += 1
             run_code.append("\n")
             run_code.append(mk_param_code_ref(call, "execute"))
             call_err = _get_error_field(call)
             if call_err:
                 run_code.extend(
                     (
-                        f"\n{mk_var_name(err_field.node_id())} = ",
+                        f"\n{err_var} = ",
                         mk_param_code_ref(call_err, "get_field_value"),
                         "\n",
                     )
@@ -152,6 +176,27 @@ class SequentialCommand(AddInTypeHandler):
                     )
                     run_code.extend(on_err_call)
                     run_code.append("\n}\n")
+            prev_err = call_err
+            prev_abort = _aborts_on_failure(call, require_all)
+        run_code.append("}\n" * open_blocks)
+
+        finally_node = node.values().get(SEQUENTIAL_FINALLY_KEY)
+        if finally_node and isinstance(finally_node, SyntaxNode):
+            for call in _get_calls(finally_node):
+                if not isinstance(call, SyntaxNode):
+                    continue
+                run_code.append("\n")
+                run_code.append(mk_param_code_ref(call, "execute"))
+                call_err = _get_error_field(call)
+                if call_err:
+                    # A cleanup failure must not hide an earlier failure.
+                    run_code.extend(
+                        (
+                            f"\nif {err_var} == nil {{\n{err_var} = ",
+                            mk_param_code_ref(call_err, "get_field_value"),
+                            "\n}\n",
+                        )
+                    )
 
         ret.append(
             GeneratedCode(
@@ -192,6 +237,25 @@ def _gen_error_func(
     return f"{mk_var_name(func_ref)}()\n"
 
 
+def _aborts_on_failure(call: SyntaxNode, require_all: bool) -> bool:
+    """Does the sequence stop if this call fails?  A fallback step follows
+    the sequence's setting if the fallback fails too."""
+    policy = get_step_policy(call)
+    if policy == STEP_POLICY_ABORT:
+        return True
+    if policy == STEP_POLICY_CONTINUE:
+        return False
+    return require_all
+
+
+def _get_calls(node: SyntaxNode) -> Sequence[SyntaxParameter]:
+    if isinstance(node.node_type(), ConstructType):
+        # Type checking shouldn't make this a thing, but here it is.
+        return (node,)
+    # Should be a list type.
+    return get_ordered_children(node)
+
+
 def _get_error_field(node: SyntaxParameter) -> Optional[SyntaxNode]:
     if not isinstance(node, SyntaxNode):
         return None
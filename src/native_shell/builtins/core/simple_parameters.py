@@ -8,6 +8,8 @@ from ...defs.node_type import (
     BOOLEAN_TYPE,
     STRING_TYPE,
     REFERENCE_TYPE,
+    DURATION_TYPE,
+    BYTE_SIZE_TYPE,
     BASIC_TYPE_IDS,
 )
 from ...helpers import create_list_type
@@ -32,6 +34,8 @@ NUMBER_LIST_TYPE = _mk_basic_list(NUMBER_TYPE)
 BOOLEAN_LIST_TYPE = _mk_basic_list(BOOLEAN_TYPE)
 STRING_LIST_TYPE = _mk_basic_list(STRING_TYPE)
 REFERENCE_LIST_TYPE = _mk_basic_list(REFERENCE_TYPE)
+DURATION_LIST_TYPE = _mk_basic_list(DURATION_TYPE)
+BYTE_SIZE_LIST_TYPE = _mk_basic_list(BYTE_SIZE_TYPE)
 UNTYPED_LIST_TYPE = create_list_type(
     source=("core", "param-types", "list(any)"),
     type_id="list(any)",
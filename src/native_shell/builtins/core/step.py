@@ -0,0 +1,183 @@
+"""A single command in a sequence, with its own failure policy."""
+
+from typing import Iterable, List, Union, Optional
+from .simple_field import ERROR_FIELD_TYPE
+from .consts import ERROR_FIELD_KEY
+from ...defs.add_ins import AddInTypeHandler, GeneratedCode, CodeReference, CodeTemplate
+from ...defs.node_type import AbcType, ConstructType, STRING_TYPE
+from ...defs.syntax_tree import SyntaxNode, SyntaxParameter
+from ...helpers import (
+    DefaultTypeField,
+    DefaultTypeParameter,
+    create_explicit_type_parameter,
+    mk_field_ref,
+    mk_var_name,
+    mk_param_code_ref,
+)
+from ...util.message import i18n as _
+from ...util.result import Result, ResultGen, Problem
+
+
+# Stop the sequence when the step fails.
+STEP_POLICY_ABORT = "abort"
+# Keep running the sequence when the step fails.
+STEP_POLICY_CONTINUE = "continue"
+# Run the fallback command when the step fails; the step then fails only if
+# the fallback fails.
+STEP_POLICY_FALLBACK = "fallback"
+STEP_POLICIES = (STEP_POLICY_ABORT, STEP_POLICY_CONTINUE, STEP_POLICY_FALLBACK)
+
+STEP_COMMAND_KEY = "command"
+STEP_COMMAND = DefaultTypeParameter(
+    key=STEP_COMMAND_KEY,
+    title=_("command"),
+    description=_("the command to run for this step"),
+    required=True,
+    type_checker=lambda t: isinstance(t, ConstructType),
+)
+
+STEP_ON_FAILURE_KEY = "on failure"
+STEP_ON_FAILURE = create_explicit_type_parameter(
+    key=STEP_ON_FAILURE_KEY,
+    title=_("on failure"),
+    description=_(
+        "what the sequence does when this step fails: 'abort', 'continue', or 'fallback'; "
+        "defaults to 'fallback' if a fallback command is given, otherwise to the "
+        "sequence's 'require all success' setting"
+    ),
+    required=False,
+    type_val=STRING_TYPE,
+)
+
+STEP_FALLBACK_KEY = "fallback"
+STEP_FALLBACK = DefaultTypeParameter(
+    key=STEP_FALLBACK_KEY,
+    title=_("fallback"),
+    description=_("command to run in place of the failed command"),
+    required=False,
+    type_checker=lambda t: isinstance(t, ConstructType),
+)
+
+STEP_ERROR_KEY = ERROR_FIELD_KEY
+STEP_ERROR = DefaultTypeField(
+    key=STEP_ERROR_KEY,
+    type_val=ERROR_FIELD_TYPE,
+    title=_("step error"),
+    description=_("The error state after the command, and the fallback if it ran."),
+    usable_before_invoking=False,
+)
+
+STEP_TYPE = ConstructType(
+    source=("core", "step"),
+    type_id="core.step",
+    title=_("step"),
+    description=_("run a command in a sequence with its own failure policy"),
+    parameters=(STEP_COMMAND, STEP_ON_FAILURE, STEP_FALLBACK),
+    fields=(STEP_ERROR,),
+)
+
+
+def get_step_policy(node: SyntaxParameter) -> Optional[str]:
+    """Get the failure policy of a step node.  Returns None if the node is not a
+    step, or the step leaves the policy to the sequence."""
+    if not isinstance(node, SyntaxNode) or node.node_type() is not STEP_TYPE:
+        return None
+    policy = node.values().get(STEP_ON_FAILURE_KEY)
+    if isinstance(policy, str):
+        return policy
+    if node.values().get(STEP_FALLBACK_KEY):
+        return STEP_POLICY_FALLBACK
+    return None
+
+
+class StepCommand(AddInTypeHandler):
+    """Run one command in a sequence.  The step's err field holds the command's
+    error, or the fallback command's error if the fallback ran.  The sequence
+    decides whether to keep going from the step's policy."""
+
+    def type(self) -> AbcType:
+        """The type representation for this handler."""
+        return STEP_TYPE
+
+    def shared_code(self) -> Iterable[GeneratedCode]:
+        """All the code that is required to include in the source
+        if this type is used."""
+        return ()
+
+    def instance_code(self, node: SyntaxNode) -> Result[Iterable[GeneratedCode]]:
+        """Constructs the code templates that this specific node in the tree
+        needs to run."""
+        res = ResultGen()
+        command = node.values().get(STEP_COMMAND_KEY)
+        if not command or not isinstance(command, SyntaxNode):
+            res.add(
+                Problem.as_validation(
+                    node.source(),
+                    _("Must supply {key} as a runnable command"),
+                    key=STEP_COMMAND_KEY,
+                )
+            )
+            return res.build(())
+
+        fallback = node.values().get(STEP_FALLBACK_KEY)
+        policy = get_step_policy(node)
+        if policy is not None and policy not in STEP_POLICIES:
+            res.add(
+                Problem.as_validation(
+                    node.source(),
+                    _("{key} must be one of {policies}, found '{policy}'"),
+                    key=STEP_ON_FAILURE_KEY,
+                    policies=", ".join(STEP_POLICIES),
+                    policy=policy,
+                )
+            )
+        elif policy == STEP_POLICY_FALLBACK and not fallback:
+            res.add(
+                Problem.as_validation(
+                    node.source(),
+                    _("{key} '{policy}' requires a {fallback} command"),
+                    key=STEP_ON_FAILURE_KEY,
+                    policy=policy,
+                    fallback=STEP_FALLBACK_KEY,
+                )
+            )
+        elif fallback and policy != STEP_POLICY_FALLBACK:
+            res.add(
+                Problem.as_validation(
+                    node.source(),
+                    _("a {fallback} command is only run with {key} '{policy}'"),
+                    fallback=STEP_FALLBACK_KEY,
+                    key=STEP_ON_FAILURE_KEY,
+                    policy=STEP_POLICY_FALLBACK,
+                )
+            )
+
+        err_var = mk_var_name(mk_field_ref(node, STEP_ERROR))
+        code: List[Union[CodeReference, str]] = ["\n", mk_param_code_ref(command, "execute")]
+        code.extend(_assign_error(err_var, command))
+        if fallback and isinstance(fallback, SyntaxNode):
+            code.append(f"\nif {err_var} != nil {{\n")
+            code.append(mk_param_code_ref(fallback, "execute"))
+            code.extend(_assign_error(err_var, fallback))
+            code.append("}\n")
+
+        return res.build(
+            (
+                GeneratedCode(
+                    ref=node.node_id(),
+                    purpose="execute",
+                    template=CodeTemplate(code),
+                ),
+            )
+        )
+
+
+STEP = StepCommand()
+
+
+def _assign_error(err_var: str, call: SyntaxNode) -> List[Union[CodeReference, str]]:
+    err = call.values().get(ERROR_FIELD_KEY)
+    if err and isinstance(err, SyntaxNode) and err.node_type() is ERROR_FIELD_TYPE:
+        return [f"\n{err_var} = ", mk_param_code_ref(err, "get_field_value"), "\n"]
+    # The command can't fail.
+    return [f"\n{err_var} = nil\n"]
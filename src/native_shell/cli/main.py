@@ -1,15 +1,26 @@
 """CLI entrypoint."""
 
-from typing import Sequence
+from typing import Sequence, Tuple, Optional
 import os
+import sys
 import argparse
 import datetime
 import hashlib
-from ..defs.script import ScriptSource
-from ..script_parser.v1 import parse_v1
+from ..defs.script import ScriptSource, InitialScript
+from ..script_parser.v1 import (
+    parse_v1,
+    detect_source_format,
+    write_script,
+    SOURCE_FORMATS,
+    WRITE_FORMATS,
+)
 from ..addin_loader import load_add_ins
 from ..astgen import generate_prepared_script
 from ..codegen import assemble_code
+from ..tooling import compute_stats, format_stats, used_construct_types
+from ..util.perf import PhaseTimer
+from ..util.report import format_problems
+from ..util.result import Result
 
 
 def cli_main(args: Sequence[str]) -> int:
@@ -24,58 +35,167 @@ def cli_main(args: Sequence[str]) -> int:
         action="store",
         help="The directory to store the generated script; defaults to the current directory.",
     )
+    parser.add_argument(
+        "--format",
+        dest="source_format",
+        action="store",
+        choices=sorted(SOURCE_FORMATS),
+        help="The script file format; defaults to detecting it from the file extension.",
+    )
+    parser.add_argument(
+        "--print-canonical",
+        dest="print_canonical",
+        action="store_true",
+        help=(
+            "Print the script in its canonical form and exit; comments are not kept, "
+            "so the script file itself is not changed.  Formats that can't be written "
+            "are printed as YAML."
+        ),
+    )
+    parser.add_argument(
+        "--dry-run",
+        dest="dry_run",
+        action="store_true",
+        help="Report the files that would be written and the types used, but write nothing.",
+    )
+    parser.add_argument(
+        "--max-problems",
+        dest="max_problems",
+        action="store",
+        type=positive_int,
+        help=(
+            "The maximum number of problems (errors, warnings, and info) to list; "
+            "defaults to listing all of them."
+        ),
+    )
+    parser.add_argument(
+        "--stats",
+        dest="stats",
+        action="store_true",
+        help="Report size and complexity statistics for the script.",
+    )
+    parser.add_argument(
+        "--debug-perf",
+        dest="debug_perf",
+        action="store_true",
+        help="Report the time taken by each processing phase and the peak memory used.",
+    )
     parser.add_argument(
         "scriptfile",
         help="The script file to transpile",
     )
 
     parsed = parser.parse_args(args[1:])
+    if parsed.print_canonical and (parsed.dry_run or parsed.stats):
+        parser.error("--print-canonical cannot be used with --dry-run or --stats")
+    timer = PhaseTimer(parsed.debug_perf)
+    ret = transpile(parsed, timer)
+    # Kept off stdout, which may carry the canonical script.
+    for line in timer.report():
+        print(line, file=sys.stderr)
+    return ret
+
+
+def positive_int(value: str) -> int:
+    """Argument type for a count that must be at least 1."""
+    try:
+        ret = int(value)
+    except ValueError:
+        ret = 0
+    if ret < 1:
+        raise argparse.ArgumentTypeError(f"must be a positive integer, found {value!r}")
+    return ret
+
+
+def transpile(parsed: argparse.Namespace, timer: PhaseTimer) -> int:
+    """Transpile the script file as requested by the parsed arguments."""
     out_dir = parsed.out_dir or os.path.curdir
     script_file = parsed.scriptfile
 
     if not os.path.isfile(script_file):
         print(f"ERROR: no such file {script_file}")
         return 1
-    if not os.path.isdir(out_dir):
-        os.makedirs(out_dir, exist_ok=True)
-        if not os.path.isdir(out_dir):
-            print(f"ERROR: could not create directory {out_dir}")
-            return 1
 
     with open(script_file, "rb") as fis:
         contents = fis.read()
 
+    source_format = parsed.source_format or detect_source_format(script_file)
+
     hash_func = hashlib.new("sha256")
     hash_func.update(contents)
 
-    res = (
-        parse_v1(
-            (
-                (
-                    ScriptSource(
-                        source=(script_file,),
-                        src_hash=hash_func.hexdigest(),
-                        when=datetime.datetime.fromtimestamp(os.path.getmtime(script_file)),
-                    ),
-                    contents,
-                ),
-            )
-        )
-        .map_result(load_add_ins)
-        .map_result(lambda script: generate_prepared_script(script, 10))
-        .map_result(assemble_code)
+    source = ScriptSource(
+        source=(script_file,),
+        src_hash=hash_func.hexdigest(),
+        when=datetime.datetime.fromtimestamp(os.path.getmtime(script_file)),
     )
-    for problem in res.problems:
-        print(str(problem))
+    initial_res = timer.run("parse", lambda: parse_v1(((source, contents),), source_format))
+    if parsed.print_canonical:
+        return print_canonical(initial_res, source_format, parsed.max_problems)
+    prepared_res = initial_res.map_result(timer.wrap("load add-ins", load_add_ins)).map_result(
+        timer.wrap("generate tree", lambda script: generate_prepared_script(script, 10))
+    )
+    res = prepared_res.map_result(timer.wrap("assemble code", assemble_code))
+    for line in format_problems(res.problems, parsed.max_problems):
+        print(line)
     if res.is_not_valid:
         return 2
 
     assembled = res.required()
-    with open(os.path.join(out_dir, "Makefile"), "w", encoding="UTF-8") as fos:
-        fos.write(assembled.makefile)
-    with open(os.path.join(out_dir, "go.mod"), "w", encoding="UTF-8") as fos:
-        fos.write(assembled.go_mod)
-    with open(os.path.join(out_dir, "main.go"), "w", encoding="UTF-8") as fos:
-        fos.write(assembled.main_go)
+    if parsed.stats:
+        for line in format_stats(compute_stats(prepared_res.required(), assembled)):
+            print(line)
+
+    outputs = (
+        ("Makefile", assembled.makefile),
+        ("go.mod", assembled.go_mod),
+        ("main.go", assembled.main_go),
+    )
 
+    if parsed.dry_run:
+        for name, _contents in outputs:
+            out_file = os.path.join(out_dir, name)
+            action = "overwrite" if os.path.exists(out_file) else "create"
+            print(f"{action} {out_file}")
+        for type_id in used_construct_types(prepared_res.required()):
+            print(f"use type {type_id}")
+        return 0
+
+    if not os.path.isdir(out_dir):
+        os.makedirs(out_dir, exist_ok=True)
+        if not os.path.isdir(out_dir):
+            print(f"ERROR: could not create directory {out_dir}")
+            return 1
+    timer.run("write", lambda: write_outputs(out_dir, outputs))
+
+    return 0
+
+
+def print_canonical(
+    initial_res: Result[InitialScript],
+    source_format: str,
+    max_problems: Optional[int],
+) -> int:
+    """Print the parsed script in its canonical form, in the same format as the source
+    when it can be written, otherwise as YAML."""
+    write_format = source_format
+    if write_format not in WRITE_FORMATS:
+        write_format = "yaml"
+        print(
+            f"NOTE: {source_format} scripts can't be written; printing the canonical form as YAML",
+            file=sys.stderr,
+        )
+    res = initial_res.map_result(lambda script: write_script(script, write_format))
+    for line in format_problems(res.problems, max_problems):
+        print(line)
+    if res.is_not_valid:
+        return 2
+    print(res.required(), end="")
     return 0
+
+
+def write_outputs(out_dir: str, outputs: Sequence[Tuple[str, str]]) -> None:
+    """Write each (file name, contents) output into the directory."""
+    for name, contents in outputs:
+        with open(os.path.join(out_dir, name), "w", encoding="UTF-8") as fos:
+            fos.write(contents)
@@ -19,5 +19,7 @@ from .basics import (
     BOOLEAN_TYPE,
     STRING_TYPE,
     REFERENCE_TYPE,
+    DURATION_TYPE,
+    BYTE_SIZE_TYPE,
     BASIC_TYPES,
 )
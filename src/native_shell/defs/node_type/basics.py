@@ -40,10 +40,26 @@ REFERENCE_TYPE = BasicType(
     description=_("reference"),
 )
 
+DURATION_TYPE = BasicType(
+    source=("built-in", "duration"),
+    type_id="duration",
+    title=_("duration"),
+    description=_("a length of time, such as '5s' or '250ms', stored as nanoseconds"),
+)
+
+BYTE_SIZE_TYPE = BasicType(
+    source=("built-in", "byte-size"),
+    type_id="byte-size",
+    title=_("byte size"),
+    description=_("a number of bytes, such as '64KiB' or '1MB', stored as bytes"),
+)
+
 BASIC_TYPES: Mapping[BasicTypeId, BasicType] = {
     "integer": INTEGER_TYPE,
     "number": NUMBER_TYPE,
     "boolean": BOOLEAN_TYPE,
     "string": STRING_TYPE,
     "reference": REFERENCE_TYPE,
+    "duration": DURATION_TYPE,
+    "byte-size": BYTE_SIZE_TYPE,
 }
@@ -240,14 +240,19 @@ class ListType(AbcType):
         return self.__title
 
 
-# Matches with SimpleParameter types.
-BasicTypeId = Literal["integer", "number", "boolean", "string", "reference"]
+# Matches with SimpleParameter types.  Durations and byte sizes are stored
+#   as integers (nanoseconds and bytes).
+BasicTypeId = Literal[
+    "integer", "number", "boolean", "string", "reference", "duration", "byte-size"
+]
 BASIC_TYPE_IDS: Sequence[BasicTypeId] = (
     "integer",
     "number",
     "boolean",
     "string",
     "reference",
+    "duration",
+    "byte-size",
 )
 
 
@@ -303,14 +303,16 @@ class ParsedSimpleNode:
 class ParsedListNode:
     """A node that contains other nodes in an ordered list."""
 
-    __slots__ = ("__id", "__parent", "__items", "__type", "__problems")
+    __slots__ = ("__id", "__parent", "__items", "__type", "__problems", "__item_type_id")
 
     def __init__(
         self,
         *,
         node_id: ParsedNodeId,
+        item_type_id: Optional[str] = None,
     ) -> None:
         self.__id = node_id
+        self.__item_type_id = item_type_id
         self.__parent: Optional[ParentReference] = None
         self.__problems = ResultGen()
         self.__items: List[AbcParsedNode] = []
@@ -326,6 +328,11 @@ class ParsedListNode:
         """The declared value type of this node.  List nodes can only be of type list."""
         return "<list>"
 
+    @property
+    def item_type_id(self) -> Optional[str]:
+        """The type declared for every item, or None if each item declares its own type."""
+        return self.__item_type_id
+
     def set_type(self, type_val: ListType) -> None:
         """Sets the list type as defined by the parent parameter.  May only be called once."""
         _ensure_type_not_set(self.node_id, self.__type)
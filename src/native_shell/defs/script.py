@@ -1,6 +1,16 @@
 """The parsed user script."""
 
-from typing import Sequence, Tuple, Iterable, Dict, Mapping, Callable, Union, Optional
+from typing import (
+    AbstractSet,
+    Sequence,
+    Tuple,
+    Iterable,
+    Dict,
+    Mapping,
+    Callable,
+    Union,
+    Optional,
+)
 import datetime
 from .add_ins import AddInTypeHandler, AddInMetaTypeHandler, AddIn
 from .node_type import AbcType, AbcMetaType
@@ -184,6 +194,8 @@ class InitialScript:
         "__bin_location",
         "__add_in_names",
         "__tree",
+        "__schema_version",
+        "__explicit_keys",
     )
 
     def __init__(
@@ -195,10 +207,14 @@ class InitialScript:
         bin_location: str,
         add_in_names: Iterable[str],
         tree: AbcParsedNode,
+        schema_version: int = 1,
+        explicit_keys: Iterable[str] = (),
     ) -> None:
         self.__source = source
         self.__name = name
         self.__version = version
+        self.__schema_version = schema_version
+        self.__explicit_keys = frozenset(explicit_keys)
         self.__bin_location = bin_location
         self.__add_in_names = tuple(add_in_names)
         self.__tree = tree
@@ -219,6 +235,17 @@ class InitialScript:
         """Version of the script."""
         return self.__version
 
+    @property
+    def schema_version(self) -> int:
+        """Version of the script file format the script was written in."""
+        return self.__schema_version
+
+    @property
+    def explicit_keys(self) -> AbstractSet[str]:
+        """The top-level script settings given in the source, rather than
+        derived by default."""
+        return self.__explicit_keys
+
     @property
     def bin_location(self) -> str:
         """Binary output file location, for the makefile."""
@@ -3,6 +3,7 @@
 from . import default_field
 from . import default_parameter
 from . import list_types
+from . import parameters
 from . import reference
 
 from .list_types import create_list_type_item_parameter, create_list_type
@@ -13,3 +14,4 @@ from .default_parameter import (
     create_delayed_list_type_parameter,
 )
 from .reference import mk_field_ref, mk_var_name, mk_param_code_ref
+from .parameters import get_parameter
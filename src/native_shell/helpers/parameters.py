@@ -0,0 +1,26 @@
+"""Lookup of nested parameter values."""
+
+from typing import Sequence, Union, Optional
+from ..defs.syntax_tree import SyntaxNode, SyntaxParameter
+
+
+def get_parameter(
+    node: SyntaxNode,
+    path: Union[str, Sequence[str]],
+) -> Optional[SyntaxParameter]:
+    """Get a parameter value nested under the node.  The path is either a
+    sequence of keys or a '.' separated string, such as "headers.accept".
+
+    Returns None if any part of the path does not exist, or if the path
+    tries to descend into a simple value.
+    """
+    keys = path.split(".") if isinstance(path, str) else path
+    current: SyntaxParameter = node
+    for key in keys:
+        if not isinstance(current, SyntaxNode):
+            return None
+        child = current.values().get(key)
+        if child is None:
+            return None
+        current = child
+    return current
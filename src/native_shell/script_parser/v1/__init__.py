@@ -1,3 +1,4 @@
 """Version 1 parser"""
 
-from .parser import parse_v1
+from .parser import parse_v1, detect_source_format, SOURCE_FORMATS
+from .writer import script_to_data, dump_json, dump_yaml, write_script, WRITE_FORMATS
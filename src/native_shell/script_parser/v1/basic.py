@@ -1,6 +1,9 @@
 """A very, very trivial script file."""
 
-from typing import List, Any
+from typing import List, Mapping, Optional, Union, Any
+import re
+import math
+from fractions import Fraction
 from ...defs.basic import mk_ref, SimpleParameter
 from ...defs.parse_tree import ParsedNodeId
 from ...defs.node_type import BasicType
@@ -59,6 +62,30 @@ def parse_basic_type(  # pylint:disable=too-many-return-statements,too-many-bran
                 )
             )
         return Result.as_value(value)
+    if as_type.type_id() == "duration":
+        duration = parse_duration(value)
+        if duration is None:
+            return Result.as_error(
+                Problem.as_validation(
+                    (*parent.source, key),
+                    _("node with type {typ} must be a duration like '5s', found {val}"),
+                    typ=repr(as_type),
+                    val=repr(value),
+                )
+            )
+        return Result.as_value(duration)
+    if as_type.type_id() == "byte-size":
+        size = parse_byte_size(value)
+        if size is None:
+            return Result.as_error(
+                Problem.as_validation(
+                    (*parent.source, key),
+                    _("node with type {typ} must be whole bytes like '64KiB', found {val}"),
+                    typ=repr(as_type),
+                    val=repr(value),
+                )
+            )
+        return Result.as_value(size)
     if as_type.type_id() == "reference":
         problems = ResultGen()
         ret: List[str] = []
@@ -93,6 +120,102 @@ def parse_basic_type(  # pylint:disable=too-many-return-statements,too-many-bran
     )
 
 
+_DURATION_UNITS: Mapping[str, int] = {
+    "ns": 1,
+    "us": 1_000,
+    "µs": 1_000,
+    "ms": 1_000_000,
+    "s": 1_000_000_000,
+    "m": 60 * 1_000_000_000,
+    "h": 60 * 60 * 1_000_000_000,
+}
+_DURATION_PART = re.compile(r"(\d+(?:\.\d*)?|\.\d+)(ns|us|µs|ms|s|m|h)")
+
+_BYTE_SIZE_UNITS: Mapping[str, int] = {
+    "": 1,
+    "b": 1,
+    "kb": 1000,
+    "mb": 1000**2,
+    "gb": 1000**3,
+    "tb": 1000**4,
+    "kib": 1024,
+    "mib": 1024**2,
+    "gib": 1024**3,
+    "tib": 1024**4,
+}
+_BYTE_SIZE = re.compile(r"(\d+(?:\.\d*)?|\.\d+)\s*([a-zA-Z]*)")
+
+# Durations and byte sizes become Go int64 values.
+_MAX_INT64 = 2**63 - 1
+
+
+def parse_duration(value: Any) -> Optional[int]:  # pylint:disable=too-many-return-statements
+    """Parse the value as a duration in nanoseconds.  Plain numbers are seconds,
+    and strings use the Go duration style, such as "1h30m", "5s", or "250ms".
+    Returns None if the value is not a duration."""
+    if isinstance(value, bool):
+        return None
+    if isinstance(value, (int, float)):
+        return _to_int64(value * _DURATION_UNITS["s"])
+    if not isinstance(value, str):
+        return None
+    text = value.strip()
+    sign = 1
+    if text[:1] in ("-", "+"):
+        sign = -1 if text[0] == "-" else 1
+        text = text[1:]
+    if text == "0":
+        return 0
+    if not text:
+        return None
+    total = 0.0
+    pos = 0
+    while pos < len(text):
+        match = _DURATION_PART.match(text, pos)
+        if not match:
+            return None
+        total += float(match.group(1)) * _DURATION_UNITS[match.group(2)]
+        pos = match.end()
+    return _to_int64(sign * total)
+
+
+def parse_byte_size(value: Any) -> Optional[int]:
+    """Parse the value as a number of bytes.  Plain numbers are bytes, and strings
+    may have a decimal (KB, MB, GB, TB) or binary (KiB, MiB, GiB, TiB) unit suffix.
+    Returns None if the value is not a byte size or is not a whole number of bytes."""
+    if isinstance(value, bool):
+        return None
+    if isinstance(value, int):
+        return _to_int64(value) if value >= 0 else None
+    if not isinstance(value, str):
+        return None
+    match = _BYTE_SIZE.fullmatch(value.strip())
+    if not match:
+        return None
+    multiplier = _BYTE_SIZE_UNITS.get(match.group(2).lower())
+    if multiplier is None:
+        return None
+    # Exact, so a fraction of a byte is caught rather than rounded away.
+    size = Fraction(match.group(1)) * multiplier
+    if size.denominator != 1:
+        return None
+    return _to_int64(size.numerator)
+
+
+def _to_int64(value: Union[int, float]) -> Optional[int]:
+    """Round the value to an integer, or None if it is infinite, not a number,
+    or too large for a Go int64."""
+    if isinstance(value, float) and not math.isfinite(value):
+        return None
+    try:
+        ret = round(value)
+    except (OverflowError, ValueError):
+        return None
+    if abs(ret) > _MAX_INT64:
+        return None
+    return ret
+
+
 def make_ref_from_path(
     parent: ParsedNodeId,
     key: str,
@@ -1,6 +1,9 @@
 """A very, very trivial script file."""
 
-from typing import Sequence, Tuple, List, Dict, Any
+from typing import Sequence, Tuple, List, Dict, Callable, Any
+import os
+import sys
+import json
 import yaml
 from .root import parse_root_node
 from ...defs.script import InitialScript, ScriptSource
@@ -8,9 +11,49 @@ from ...util.message import UserMessage
 from ...util.message import i18n as _
 from ...util.result import Result, Problem, ResultGen
 
+# The standard library only includes a TOML reader from Python 3.11.
+if sys.version_info >= (3, 11):
+    import tomllib
+else:
+    import tomli as tomllib
 
-def parse_v1(source: Sequence[Tuple[ScriptSource, bytes]]) -> Result[InitialScript]:
-    """Parse v1 of the script."""
+# The newest script file format this parser understands.
+SCHEMA_VERSION = 1
+
+
+def load_toml(contents: bytes) -> Any:
+    """Load TOML contents."""
+    return tomllib.loads(contents.decode("utf-8"))
+
+
+# The top-level script settings; every other top-level key is a node.
+HEADER_KEYS = ("schema-version", "name", "version", "bin", "require-libs")
+
+# Source formats that load into the v1 script structure, by name.
+SOURCE_FORMATS: Dict[str, Callable[[bytes], Any]] = {
+    "yaml": yaml.safe_load,
+    "json": json.loads,
+    "toml": load_toml,
+}
+
+# File extensions for the source formats; anything else is read as YAML.
+_FORMAT_EXTENSIONS = {
+    ".json": "json",
+    ".toml": "toml",
+}
+
+
+def detect_source_format(filename: str) -> str:
+    """Find the source format for the file, based on its extension."""
+    return _FORMAT_EXTENSIONS.get(os.path.splitext(filename)[1].lower(), "yaml")
+
+
+def parse_v1(
+    source: Sequence[Tuple[ScriptSource, bytes]],
+    source_format: str = "yaml",
+) -> Result[InitialScript]:
+    """Parse v1 of the script.  The contents are read with the loader
+    for the source format, and each format shares the same structure."""
     if len(source) != 1:
         return Result.as_error(
             Problem.as_validation(
@@ -19,9 +62,19 @@ def parse_v1(source: Sequence[Tuple[ScriptSource, bytes]]) -> Result[InitialScri
             )
         )
     script_source, script_contents = source[0]
+    loader = SOURCE_FORMATS.get(source_format)
+    if loader is None:
+        return Result.as_error(
+            Problem.as_validation(
+                script_source.source,
+                _("unknown source format '{fmt}'; must be one of {formats}"),
+                fmt=source_format,
+                formats=", ".join(sorted(SOURCE_FORMATS)),
+            )
+        )
 
     try:
-        raw_data = yaml.safe_load(script_contents)
+        raw_data = loader(script_contents)
     except Exception as err:  # pylint:disable=broad-except
         return Result.as_error(
             Problem(
@@ -42,6 +95,11 @@ def parse_v1(source: Sequence[Tuple[ScriptSource, bytes]]) -> Result[InitialScri
         )
 
     res = ResultGen()
+    explicit_keys = [key for key in HEADER_KEYS if key in raw_data]
+    schema_version = parse_schema_version(script_source, raw_data, res)
+    if res.is_not_valid():
+        # Don't guess at the meaning of an unsupported format.
+        return Result.as_error(res.problems)
     script_name = parse_script_name(script_source, raw_data, res)
     script_version = parse_script_version(script_source, raw_data, res)
     bin_location = parse_bin_location(script_source, script_name, raw_data, res)
@@ -56,10 +114,50 @@ def parse_v1(source: Sequence[Tuple[ScriptSource, bytes]]) -> Result[InitialScri
             bin_location=bin_location,
             add_in_names=add_ins,
             tree=tree,
+            schema_version=schema_version,
+            explicit_keys=explicit_keys,
         )
     )
 
 
+def parse_schema_version(
+    script_source: ScriptSource,
+    data: Dict[str, Any],
+    res: ResultGen,
+) -> int:
+    """Extract the script file format version from the source."""
+    if "schema-version" in data:
+        schema_version = data["schema-version"]
+        # Remove the field so it isn't picked up by the root parser.
+        del data["schema-version"]
+        if (
+            not isinstance(schema_version, int)
+            or isinstance(schema_version, bool)
+            or schema_version < 1
+        ):
+            res.add(
+                Problem.as_validation(
+                    (*script_source.source, "schema-version"),
+                    _("'schema-version' if given must be a positive integer"),
+                )
+            )
+        elif schema_version > SCHEMA_VERSION:
+            res.add(
+                Problem.as_validation(
+                    (*script_source.source, "schema-version"),
+                    _(
+                        "script requires schema-version {version}, but this parser "
+                        "only supports up to {supported}"
+                    ),
+                    version=schema_version,
+                    supported=SCHEMA_VERSION,
+                )
+            )
+        else:
+            return schema_version
+    return SCHEMA_VERSION
+
+
 def parse_script_name(
     script_source: ScriptSource,
     data: Dict[str, Any],
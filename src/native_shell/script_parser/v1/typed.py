@@ -1,8 +1,8 @@
 """A very, very trivial script file."""
 
-from typing import Dict, Optional, Any, cast
+from typing import Dict, Sequence, Optional, Any, cast
 from .basic import parse_basic_type
-from ...defs.basic import mk_ref
+from ...defs.basic import mk_ref, SimpleParameter
 from ...defs.parse_tree import (
     AbcParsedNode,
     ParsedNodeId,
@@ -12,7 +12,8 @@ from ...defs.parse_tree import (
 )
 from ...defs.node_type import BASIC_TYPES, BasicType, BasicTypeId
 from ...util.message import i18n as _
-from ...util.result import Problem, ResultGen
+from ...util.result import Result, Problem, ResultGen, SourcePath
+from ...util.suggest import closest_match
 
 
 # R0912 too-many-branches
@@ -29,21 +30,17 @@ def parse_typed_node(  # pylint:disable=R0915,R0914,R0911,R0912
     """For now, do nothing."""
     if not data:
         return None
+    # Parsing removes the keys as they are read.  YAML anchors and aliases share
+    # the same dictionary between each use, so work from a copy.
+    data = dict(data)
 
     is_list: bool
     with_list_items = data.get("with-list")
     if with_list_items:
         # A list of values, each with their own type.
         del data["with-list"]
-        if data.keys():
-            # Don't stop
-            res.add(
-                Problem.as_validation(
-                    (*parent.source, node_key),
-                    _("type supports only 'with-list'; found additional: {keys}"),
-                    keys=data.keys(),
-                )
-            )
+        # Don't stop
+        _report_additional_keys((*parent.source, node_key), data, ("with-list",), res)
         if not isinstance(with_list_items, (tuple, list)):
             res.add(
                 Problem.as_validation(
@@ -96,6 +93,8 @@ def parse_typed_node(  # pylint:disable=R0915,R0914,R0911,R0912
         as_type = as_list_type
         name = "as-list"
         is_list = True
+        # Having both is already reported above.
+        data.pop("as", None)
     if not isinstance(as_type, str):
         res.add(
             Problem.as_validation(
@@ -145,16 +144,32 @@ def parse_typed_node(  # pylint:disable=R0915,R0914,R0911,R0912
                 source=(*parent.source, node_key),
                 ref=mk_ref((*parent.ref, node_key)),
             ),
+            item_type_id=as_type,
         )
         ret = pln
         for item in value_list:
             if not isinstance(item, dict):
-                res.add(
-                    Problem.as_validation(
-                        (*parent.source, node_key, "items"),
-                        _("type with an 'items' must have each one be a dict"),
+                basic_type = closest_match(as_type, BASIC_TYPES.keys())
+                if basic_type:
+                    # Most likely a misspelled basic type.
+                    res.add(
+                        Problem.as_validation(
+                            (*parent.source, node_key, "items"),
+                            _(
+                                "'{typ}' is not a basic type, so each of its 'items' "
+                                "must be a dict; did you mean '{suggest}'?"
+                            ),
+                            typ=as_type,
+                            suggest=basic_type,
+                        )
+                    )
+                else:
+                    res.add(
+                        Problem.as_validation(
+                            (*parent.source, node_key, "items"),
+                            _("type with an 'items' must have each one be a dict"),
+                        )
                     )
-                )
                 continue
             # This implies that parameters cannot be lists of lists, which is fine.
             node = parse_parameter_node(
@@ -163,10 +178,14 @@ def parse_typed_node(  # pylint:disable=R0915,R0914,R0911,R0912
                 data=item,
                 res=res,
                 as_type=as_type,
+                allowed=("with",),
             )
             if node:
                 pln.add_value(node)
+        # Not a stop-right-now error
+        _report_additional_keys((*parent.source, node_key), data, ("as-list", "items"), res)
     else:
+        # This reports its own additional keys.
         ret = parse_parameter_node(
             parent=parent,
             node_key=node_key,
@@ -174,16 +193,6 @@ def parse_typed_node(  # pylint:disable=R0915,R0914,R0911,R0912
             res=res,
             as_type=as_type,
         )
-
-    if data:
-        # Not a stop-right-now error
-        res.add(
-            Problem.as_validation(
-                (*parent.source, node_key),
-                _("type supports only 'items' or 'with'; found additional: {keys}"),
-                keys=repr(tuple(data.keys())),
-            )
-        )
     return ret
 
 
@@ -194,16 +203,44 @@ def parse_parameter_node(
     data: Dict[str, Any],
     res: ResultGen,
     as_type: str,
+    allowed: Sequence[str] = ("as", "with"),
 ) -> Optional[AbcParsedNode]:
-    """Parse the data into a parameter node."""
+    """Parse the data into a parameter node.  The allowed keys are those this
+    form of node may have; list items take their type from the list."""
+    # List items come here directly, and may also be shared YAML aliases.
+    data = dict(data)
     with_val = data.get("with")
     if with_val is None or not isinstance(with_val, dict):
-        res.add(
-            Problem.as_validation(
-                (*parent.source, node_key),
-                _("must have a 'with' setting"),
+        basic_type = closest_match(as_type, BASIC_TYPES.keys())
+        with_key = closest_match("with", (str(key) for key in data.keys() if key != "with"))
+        if with_key:
+            res.add(
+                Problem.as_validation(
+                    (*parent.source, node_key, with_key),
+                    _("must have a 'with' setting; did you mean 'with' instead of '{key}'?"),
+                    key=with_key,
+                )
+            )
+        elif basic_type and ("value" in data or "items" in data):
+            # Most likely a misspelled basic type.
+            res.add(
+                Problem.as_validation(
+                    (*parent.source, node_key),
+                    _(
+                        "'{typ}' is not a basic type, so must have a 'with' setting; "
+                        "did you mean '{suggest}'?"
+                    ),
+                    typ=as_type,
+                    suggest=basic_type,
+                )
+            )
+        else:
+            res.add(
+                Problem.as_validation(
+                    (*parent.source, node_key),
+                    _("must have a 'with' setting"),
+                )
             )
-        )
         return None
     del data["with"]
 
@@ -233,15 +270,8 @@ def parse_parameter_node(
         if parsed:
             ret.set_parameter(key, parsed)
 
-    if data:
-        # Not a stop-right-now error
-        res.add(
-            Problem.as_validation(
-                (*parent.source, node_key),
-                _("type supports only 'as' and 'with'; found additional: {keys}"),
-                keys=repr(tuple(data.keys())),
-            )
-        )
+    # Not a stop-right-now error
+    _report_additional_keys((*parent.source, node_key), data, allowed, res)
     return ret
 
 
@@ -282,6 +312,7 @@ def parse_basic_node(
                 source=(*parent.source, node_key),
                 ref=mk_ref((*parent.ref, node_key)),
             ),
+            item_type_id=as_type.type_id(),
         )
         ret = pln
         for item in value_list:
@@ -294,7 +325,7 @@ def parse_basic_node(
                         ref=mk_ref((*parent.ref, node_key)),
                     ),
                     type_val=as_type,
-                    value=simple.optional() or "",
+                    value=_simple_value(simple),
                 )
             )
     else:
@@ -319,16 +350,53 @@ def parse_basic_node(
                 ref=mk_ref((*parent.ref, node_key)),
             ),
             type_val=as_type,
-            value=simple.optional() or "",
+            value=_simple_value(simple),
         )
 
-    if data:
-        # Not a stop-right-now error
-        res.add(
-            Problem.as_validation(
-                (*parent.source, node_key),
-                _("basic type supports only 'list' or 'value'; found additional: {keys}"),
-                keys=repr(tuple(data.keys())),
-            )
-        )
+    # Not a stop-right-now error
+    _report_additional_keys(
+        (*parent.source, node_key),
+        data,
+        ("as-list", "items") if is_list else ("as", "value"),
+        res,
+    )
     return ret
+
+
+def _report_additional_keys(
+    source: SourcePath,
+    data: Dict[str, Any],
+    allowed: Sequence[str],
+    res: ResultGen,
+) -> None:
+    """Report each key left over after parsing the node, which the node's
+    form does not allow."""
+    for key in data.keys():
+        # A key can be in the wrong place for this form of node, so never suggest it for itself.
+        suggest = closest_match(str(key), (name for name in allowed if name != key))
+        if suggest:
+            res.add(
+                Problem.as_validation(
+                    (*source, str(key)),
+                    _("unknown key '{key}'; did you mean '{suggest}'?"),
+                    key=key,
+                    suggest=suggest,
+                )
+            )
+        else:
+            res.add(
+                Problem.as_validation(
+                    (*source, str(key)),
+                    _("unknown key '{key}'; this node only allows {allowed}"),
+                    key=key,
+                    allowed=", ".join(f"'{name}'" for name in allowed),
+                )
+            )
+
+
+def _simple_value(simple: Result[SimpleParameter]) -> SimpleParameter:
+    # Falsy values, such as 0 or false, are still valid values.
+    value = simple.optional()
+    if value is None:
+        return ""
+    return value
@@ -0,0 +1,136 @@
+"""Writes a parsed script back out in the v1 script structure."""
+
+from typing import Dict, Callable, Any
+import json
+import yaml
+from ...defs.basic import SimpleParameter
+from ...defs.parse_tree import (
+    AbcParsedNode,
+    ParsedSimpleNode,
+    ParsedListNode,
+    ParsedParameterNode,
+)
+from ...defs.script import InitialScript
+from ...util.message import i18n as _
+from ...util.result import Result, Problem
+
+
+_DURATION_UNITS = (
+    ("h", 3_600_000_000_000),
+    ("m", 60_000_000_000),
+    ("s", 1_000_000_000),
+    ("ms", 1_000_000),
+    ("us", 1_000),
+)
+
+
+def script_to_data(script: InitialScript) -> Dict[str, Any]:
+    """Convert the script into the v1 data structure, which parse_v1 reads back
+    into an equivalent script.  Top-level settings are only included if the
+    source gave them, so defaults the parser derived stay derived."""
+    ret: Dict[str, Any] = {}
+    header: Dict[str, Any] = {
+        "schema-version": script.schema_version,
+        "name": script.name,
+        "version": script.version,
+        "bin": script.bin_location,
+        # The parser always adds 'core' as the first add-in.
+        "require-libs": list(script.add_in_names[1:]),
+    }
+    for key, value in header.items():
+        if key in script.explicit_keys:
+            ret[key] = value
+    for key, node in script.tree.mapping().items():
+        ret[str(key)] = node_to_data(node)
+    return ret
+
+
+def node_to_data(node: AbcParsedNode) -> Dict[str, Any]:
+    """Convert a single parsed node into the v1 data structure."""
+    if isinstance(node, ParsedSimpleNode):
+        return {"as": node.type_id, "value": simple_to_data(node.type_id, node.value)}
+    if isinstance(node, ParsedParameterNode):
+        return {
+            "as": node.type_id,
+            "with": {str(key): node_to_data(child) for key, child in node.mapping().items()},
+        }
+    if isinstance(node, ParsedListNode):
+        return _list_to_data(node)
+    raise RuntimeError(f"No known conversion for {node!r}")
+
+
+def simple_to_data(type_id: str, value: SimpleParameter) -> Any:
+    """Convert a simple value into the form the v1 parser reads."""
+    if type_id == "duration" and isinstance(value, int):
+        return format_duration(value)
+    if isinstance(value, tuple):
+        return list(value)
+    return value
+
+
+def format_duration(nanoseconds: int) -> str:
+    """Format the duration with the largest unit that keeps it exact."""
+    if nanoseconds == 0:
+        return "0s"
+    for unit, size in _DURATION_UNITS:
+        if nanoseconds % size == 0:
+            return f"{nanoseconds // size}{unit}"
+    return f"{nanoseconds}ns"
+
+
+def dump_json(script: InitialScript) -> str:
+    """Write the script as JSON.  The v1 parser reads this back directly."""
+    return json.dumps(script_to_data(script), indent=2, ensure_ascii=False) + "\n"
+
+
+def dump_yaml(script: InitialScript) -> str:
+    """Write the script as YAML, keeping the order of the nodes."""
+    return yaml.safe_dump(
+        script_to_data(script),
+        sort_keys=False,
+        allow_unicode=True,
+        default_flow_style=False,
+    )
+
+
+# The formats write_script can produce, by name.
+WRITE_FORMATS: Dict[str, Callable[[InitialScript], str]] = {
+    "yaml": dump_yaml,
+    "json": dump_json,
+}
+
+
+def write_script(script: InitialScript, fmt: str) -> Result[str]:
+    """Write the script in the canonical form for the named format.
+
+    The parsers drop comments, so a script written from a parsed script
+    will not include any comments from the original source."""
+    writer = WRITE_FORMATS.get(fmt)
+    if writer is None:
+        return Result.as_error(
+            Problem.as_validation(
+                ("writer",),
+                _("unknown script format '{fmt}'; must be one of {formats}"),
+                fmt=fmt,
+                formats=", ".join(sorted(WRITE_FORMATS)),
+            )
+        )
+    return Result.as_value(writer(script))
+
+
+def _list_to_data(node: ParsedListNode) -> Dict[str, Any]:
+    if node.item_type_id is None:
+        return {"with-list": [node_to_data(item) for item in node.values()]}
+    return {
+        "as-list": node.item_type_id,
+        "items": [_list_item_to_data(item) for item in node.values()],
+    }
+
+
+def _list_item_to_data(node: AbcParsedNode) -> Any:
+    """Convert an item of an 'as-list', which takes its type from the list."""
+    if isinstance(node, ParsedSimpleNode):
+        return simple_to_data(node.type_id, node.value)
+    if isinstance(node, ParsedParameterNode):
+        return {"with": {str(key): node_to_data(child) for key, child in node.mapping().items()}}
+    raise RuntimeError(f"No known list item conversion for {node!r}")
@@ -0,0 +1,7 @@
+"""Script tooling that inspects the generated syntax tree."""
+
+from . import stats
+from . import usage
+
+from .stats import ScriptStats, compute_stats, format_stats
+from .usage import used_construct_types
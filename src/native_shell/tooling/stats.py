@@ -0,0 +1,116 @@
+"""Size and complexity statistics for a prepared script."""
+
+from typing import Sequence, List, Tuple, Dict, Mapping, Optional
+from ..codegen import AssembledCode
+from ..defs.script import PreparedScript
+from ..defs.syntax_tree import SyntaxNode
+from .usage import script_sections, written_children, written_values
+
+
+class ScriptStats:  # pylint:disable=too-few-public-methods
+    """Statistics gathered from the syntax tree and the generated code."""
+
+    __slots__ = (
+        "node_count",
+        "type_counts",
+        "section_counts",
+        "section_type_counts",
+        "max_depth",
+        "max_fan_out",
+        "generated_size",
+    )
+
+    def __init__(
+        self,
+        *,
+        node_count: int,
+        type_counts: Mapping[str, int],
+        section_counts: Mapping[str, int],
+        section_type_counts: Mapping[str, Mapping[str, int]],
+        max_depth: int,
+        max_fan_out: int,
+        generated_size: Optional[int],
+    ) -> None:
+        self.node_count = node_count
+        self.type_counts = dict(type_counts)
+        self.section_counts = dict(section_counts)
+        self.section_type_counts = {
+            section: dict(counts) for section, counts in section_type_counts.items()
+        }
+        self.max_depth = max_depth
+        self.max_fan_out = max_fan_out
+        self.generated_size = generated_size
+
+
+def compute_stats(script: PreparedScript, code: Optional[AssembledCode] = None) -> ScriptStats:
+    """Walk the script's syntax tree to gather the statistics.  If the assembled
+    code is given, then its size is included."""
+    type_counts: Dict[str, int] = {}
+    # Node counts under each top-level script section, such as 'main'.
+    section_counts: Dict[str, int] = {}
+    # Type usage under each top-level script section.
+    section_type_counts: Dict[str, Dict[str, int]] = {}
+    node_count = 0
+    max_depth = 0
+    max_fan_out = 0
+
+    # Only the nodes written in the script are counted; the generated root
+    # node and the field nodes that constructs add are left out.
+    # Not recursive, so stack size isn't a concern.
+    stack: List[Tuple[SyntaxNode, int, str]] = [
+        (child, 1, key) for key, child in script_sections(script)
+    ]
+    while stack:
+        node, depth, section = stack.pop()
+        node_count += 1
+        type_id = node.node_type().type_id()
+        type_counts[type_id] = type_counts.get(type_id, 0) + 1
+        section_counts[section] = section_counts.get(section, 0) + 1
+        section_types = section_type_counts.setdefault(section, {})
+        section_types[type_id] = section_types.get(type_id, 0) + 1
+        max_depth = max(max_depth, depth)
+        max_fan_out = max(max_fan_out, len(written_values(node)))
+        for _key, child in written_children(node):
+            stack.append((child, depth + 1, section))
+
+    generated_size: Optional[int] = None
+    if code is not None:
+        generated_size = sum(
+            len(text.encode("utf-8")) for text in (code.makefile, code.go_mod, code.main_go)
+        )
+
+    return ScriptStats(
+        node_count=node_count,
+        type_counts=type_counts,
+        section_counts=section_counts,
+        section_type_counts=section_type_counts,
+        max_depth=max_depth,
+        max_fan_out=max_fan_out,
+        generated_size=generated_size,
+    )
+
+
+def format_stats(stats: ScriptStats) -> Sequence[str]:
+    """Turn the statistics into report lines."""
+    ret = [
+        f"nodes: {stats.node_count}",
+        f"distinct types: {len(stats.type_counts)}",
+        f"max depth: {stats.max_depth}",
+        f"max fan-out: {stats.max_fan_out}",
+    ]
+    if stats.generated_size is not None:
+        ret.append(f"generated code size: {stats.generated_size} bytes")
+    ret.append("nodes by type:")
+    for type_id, count in _by_usage(stats.type_counts):
+        ret.append(f"  {type_id}: {count}")
+    ret.append("nodes by section:")
+    for section, count in sorted(stats.section_counts.items()):
+        ret.append(f"  {section}: {count}")
+        for type_id, type_count in _by_usage(stats.section_type_counts.get(section, {})):
+            ret.append(f"    {type_id}: {type_count}")
+    return ret
+
+
+def _by_usage(counts: Mapping[str, int]) -> List[Tuple[str, int]]:
+    # Most used types first, then by name for a stable order.
+    return sorted(counts.items(), key=lambda t: (-t[1], t[0]))
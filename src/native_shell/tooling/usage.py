@@ -0,0 +1,47 @@
+"""Which types a prepared script uses."""
+
+from typing import List, Set, Dict, Tuple
+from ..defs.node_type import ConstructType
+from ..defs.script import PreparedScript
+from ..defs.syntax_tree import SyntaxNode, SyntaxParameter
+
+
+def used_construct_types(script: PreparedScript) -> List[str]:
+    """Get the sorted construct type ids the script's nodes are declared with.
+    This leaves out the generated root type and the field nodes that
+    constructs add for their own values."""
+    found: Set[str] = set()
+    # Not recursive, so stack size isn't a concern.
+    stack: List[SyntaxNode] = [child for _key, child in script_sections(script)]
+    while stack:
+        node = stack.pop()
+        node_type = node.node_type()
+        if isinstance(node_type, ConstructType):
+            found.add(node_type.type_id())
+        stack.extend(child for _key, child in written_children(node))
+    return sorted(found)
+
+
+def script_sections(script: PreparedScript) -> List[Tuple[str, SyntaxNode]]:
+    """Get the top-level nodes of the script, such as 'main', by key.  The
+    generated root node that holds them is left out."""
+    return [
+        (key, child) for key, child in script.tree.values().items() if isinstance(child, SyntaxNode)
+    ]
+
+
+def written_values(node: SyntaxNode) -> Dict[str, SyntaxParameter]:
+    """Get the node's values that came from the script, leaving out the field
+    nodes that a construct adds for its own values."""
+    node_type = node.node_type()
+    field_keys: Set[str] = set()
+    if isinstance(node_type, ConstructType):
+        field_keys = {field.key() for field in node_type.fields()}
+    return {key: value for key, value in node.values().items() if key not in field_keys}
+
+
+def written_children(node: SyntaxNode) -> List[Tuple[str, SyntaxNode]]:
+    """Get the child nodes that came from the script, by key."""
+    return [
+        (key, child) for key, child in written_values(node).items() if isinstance(child, SyntaxNode)
+    ]
@@ -0,0 +1,51 @@
+"""Self-profiling of the tool's processing phases."""
+
+from typing import List, Tuple, Callable, TypeVar
+import time
+import tracemalloc
+
+
+_T = TypeVar("_T")
+_R = TypeVar("_R")
+
+
+class PhaseTimer:
+    """Records how long each processing phase takes, and the peak memory used.
+
+    When not enabled, the phases are run without any measurement.
+    """
+
+    __slots__ = ("__enabled", "__phases")
+
+    def __init__(self, enabled: bool) -> None:
+        self.__enabled = enabled
+        self.__phases: List[Tuple[str, float]] = []
+        if enabled:
+            tracemalloc.start()
+
+    def run(self, name: str, callback: Callable[[], _R]) -> _R:
+        """Run the callback as the named phase."""
+        if not self.__enabled:
+            return callback()
+        start = time.perf_counter()
+        try:
+            return callback()
+        finally:
+            self.__phases.append((name, time.perf_counter() - start))
+
+    def wrap(self, name: str, callback: Callable[[_T], _R]) -> Callable[[_T], _R]:
+        """Wrap the single argument callback so that calling it runs the named phase."""
+        return lambda value: self.run(name, lambda: callback(value))
+
+    def report(self) -> List[str]:
+        """Stop measuring and create the report lines.  Returns nothing if not enabled."""
+        if not self.__enabled:
+            return []
+        ret = [f"phase {name}: {seconds * 1000.0:.2f} ms" for name, seconds in self.__phases]
+        total = sum(seconds for _name, seconds in self.__phases)
+        ret.append(f"total: {total * 1000.0:.2f} ms")
+        if tracemalloc.is_tracing():
+            _current, peak = tracemalloc.get_traced_memory()
+            tracemalloc.stop()
+            ret.append(f"peak memory: {peak / 1024.0:.1f} KiB")
+        return ret
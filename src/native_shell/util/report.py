@@ -0,0 +1,61 @@
+"""Formats problems for reporting to the user."""
+
+from typing import Sequence, List, Tuple, Dict, Union, Optional
+from .result import Problem, ProblemLevel, SourcePathElement
+
+
+_LEVEL_ORDER: Dict[ProblemLevel, int] = {"error": 0, "warning": 1, "info": 2}
+
+
+def format_problems(
+    problems: Sequence[Problem],
+    max_count: Optional[int] = None,
+) -> List[str]:
+    """Create the report lines for the problems.
+
+    Problems are grouped by their source file (the first element of the
+    source path), then by node, and sorted by source path.  At most max_count
+    problems are listed, followed by a count of the omitted ones.  The report
+    ends with a count of the problems by level.
+    """
+    if not problems:
+        return []
+
+    ordered = sorted(
+        problems,
+        key=lambda p: (_path_key(p.source), _LEVEL_ORDER[p.level]),
+    )
+    shown = ordered if max_count is None else ordered[: max(0, max_count)]
+
+    ret: List[str] = []
+    current_file: Optional[str] = None
+    current_node: Optional[str] = None
+    for problem in shown:
+        source_file = str(problem.source[0]) if problem.source else ""
+        node = "/".join(str(p) for p in problem.source[1:])
+        if source_file != current_file:
+            ret.append(f"{source_file}:")
+            current_file = source_file
+            current_node = None
+        if node != current_node:
+            if node:
+                ret.append(f"  {node}:")
+            current_node = node
+        indent = "    " if node else "  "
+        ret.append(f"{indent}[{problem.level.upper()}] {problem.msg()}")
+
+    if len(shown) < len(ordered):
+        ret.append(f"... and {len(ordered) - len(shown)} more")
+
+    counts = {level: 0 for level in _LEVEL_ORDER}
+    for problem in problems:
+        counts[problem.level] += 1
+    ret.append(f"{counts['error']} error(s), {counts['warning']} warning(s), {counts['info']} info")
+    return ret
+
+
+def _path_key(
+    path: Sequence[SourcePathElement],
+) -> Tuple[Tuple[int, Union[int, str]], ...]:
+    # Paths mix integer and string elements, which can't be directly compared.
+    return tuple((0, p) if isinstance(p, int) else (1, p) for p in path)
@@ -68,6 +68,11 @@ class Problem:
             message=UserMessage(__message, **__arguments),
         )
 
+    @property
+    def level(self) -> ProblemLevel:
+        """The severity of the problem."""
+        return self._level
+
     @property
     def is_error(self) -> bool:
         """Is this an error-level problem?"""
@@ -0,0 +1,12 @@
+"""Suggest the intended name for a misspelled one."""
+
+from typing import Iterable, Optional
+import difflib
+
+
+def closest_match(name: str, candidates: Iterable[str]) -> Optional[str]:
+    """Find the candidate closest to the name, or None if none are close."""
+    found = difflib.get_close_matches(name, tuple(candidates), n=1)
+    if found:
+        return found[0]
+    return None
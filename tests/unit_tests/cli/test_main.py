@@ -1,6 +1,13 @@
 """Test the module."""
 
+from typing import Tuple
 import unittest
+import os
+import io
+import json
+import tempfile
+import contextlib
+import yaml
 from native_shell.cli import main
 
 
@@ -14,3 +21,173 @@ class MainTest(unittest.TestCase):
             main.cli_main(["cli-main", "--help"])
         except SystemExit as err:
             self.assertEqual(0, err.code)
+
+    def test_cli_main__dry_run(self) -> None:
+        """Test the main program with a dry run; nothing is written."""
+        with tempfile.TemporaryDirectory() as tmp_dir:
+            script_file = os.path.join(tmp_dir, "script.yaml")
+            with open(script_file, "w", encoding="utf-8") as fos:
+                fos.write(SCRIPT_1)
+            out_dir = os.path.join(tmp_dir, "out")
+            with open(os.path.join(tmp_dir, "go.mod"), "w", encoding="utf-8") as fos:
+                fos.write("existing")
+
+            out = io.StringIO()
+            with contextlib.redirect_stdout(out):
+                ret = main.cli_main(["cli-main", "--dry-run", "--out", out_dir, script_file])
+            self.assertEqual(0, ret)
+            self.assertFalse(os.path.exists(out_dir))
+            lines = out.getvalue().splitlines()
+            self.assertIn(f"create {os.path.join(out_dir, 'main.go')}", lines)
+            self.assertIn("use type core.echo", lines)
+            # Internal types are not listed.
+            self.assertEqual(["use type core.echo"], [l for l in lines if l.startswith("use ")])
+
+            out = io.StringIO()
+            with contextlib.redirect_stdout(out):
+                ret = main.cli_main(["cli-main", "--dry-run", "--out", tmp_dir, script_file])
+            self.assertEqual(0, ret)
+            lines = out.getvalue().splitlines()
+            self.assertIn(f"overwrite {os.path.join(tmp_dir, 'go.mod')}", lines)
+            self.assertIn(f"create {os.path.join(tmp_dir, 'Makefile')}", lines)
+            with open(os.path.join(tmp_dir, "go.mod"), "r", encoding="utf-8") as fis:
+                self.assertEqual("existing", fis.read())
+
+    def test_cli_main__json(self) -> None:
+        """Test that a .json script file is read as JSON."""
+        with tempfile.TemporaryDirectory() as tmp_dir:
+            script_file = os.path.join(tmp_dir, "script.json")
+            with open(script_file, "w", encoding="utf-8") as fos:
+                fos.write(SCRIPT_1_JSON)
+            out = io.StringIO()
+            with contextlib.redirect_stdout(out):
+                ret = main.cli_main(["cli-main", "--dry-run", "--out", tmp_dir, script_file])
+            self.assertEqual(0, ret)
+            self.assertIn("use type core.echo", out.getvalue().splitlines())
+
+    def test_cli_main__print_canonical(self) -> None:
+        """Test printing the canonical form; it parses back to the same script."""
+        with tempfile.TemporaryDirectory() as tmp_dir:
+            script_file = os.path.join(tmp_dir, "script.yaml")
+            with open(script_file, "w", encoding="utf-8") as fos:
+                fos.write("# a comment\n" + SCRIPT_1)
+            ret, text, _err = _run_main("--print-canonical", script_file)
+            self.assertEqual(0, ret)
+            self.assertTrue(text.startswith("name: test-echo\nmain:\n"))
+            with open(script_file, "r", encoding="utf-8") as fis:
+                self.assertEqual("# a comment\n" + SCRIPT_1, fis.read())
+            self.assertEqual(["script.yaml"], os.listdir(tmp_dir))
+
+            # Formatting the canonical form gives the same text.
+            with open(script_file, "w", encoding="utf-8") as fos:
+                fos.write(text)
+            ret, out, _err = _run_main("--print-canonical", script_file)
+            self.assertEqual(0, ret)
+            self.assertEqual(text, out)
+
+    def test_cli_main__print_canonical_json(self) -> None:
+        """Test printing the canonical form of a JSON script, which stays JSON."""
+        with tempfile.TemporaryDirectory() as tmp_dir:
+            script_file = os.path.join(tmp_dir, "script.json")
+            with open(script_file, "w", encoding="utf-8") as fos:
+                fos.write(SCRIPT_1_JSON)
+            ret, out, err = _run_main("--print-canonical", script_file)
+            self.assertEqual(0, ret)
+            self.assertEqual("", err)
+            self.assertEqual("test-echo", json.loads(out)["name"])
+
+    def test_cli_main__print_canonical_toml(self) -> None:
+        """Test printing the canonical form of a TOML script, which is printed as YAML."""
+        with tempfile.TemporaryDirectory() as tmp_dir:
+            script_file = os.path.join(tmp_dir, "script.toml")
+            with open(script_file, "w", encoding="utf-8") as fos:
+                fos.write(SCRIPT_1_TOML)
+            ret, out, err = _run_main("--print-canonical", script_file)
+            self.assertEqual(0, ret)
+            self.assertIn("printing the canonical form as YAML", err)
+            self.assertEqual("test-echo", yaml.safe_load(out)["name"])
+
+    def test_cli_main__print_canonical_debug_perf(self) -> None:
+        """Test that the performance report stays out of the printed canonical form."""
+        with tempfile.TemporaryDirectory() as tmp_dir:
+            script_file = os.path.join(tmp_dir, "script.yaml")
+            with open(script_file, "w", encoding="utf-8") as fos:
+                fos.write(SCRIPT_1)
+            ret, out, err = _run_main("--print-canonical", "--debug-perf", script_file)
+            self.assertEqual(0, ret)
+            self.assertEqual("test-echo", yaml.safe_load(out)["name"])
+            self.assertNotIn("total:", out)
+            self.assertIn("total:", err)
+
+    def test_cli_main__print_canonical_conflicts(self) -> None:
+        """Test that the canonical form can't be combined with code generation options."""
+        for option in ("--dry-run", "--stats"):
+            with self.assertRaises(SystemExit) as err:
+                _run_main("--print-canonical", option, "script.yaml")
+            self.assertEqual(2, err.exception.code)
+
+    def test_cli_main__max_problems(self) -> None:
+        """Test that the problem cap must be a positive count."""
+        for value in ("0", "-1", "many"):
+            with self.assertRaises(SystemExit) as err:
+                _run_main("--max-problems", value, "script.yaml")
+            self.assertEqual(2, err.exception.code)
+
+        with tempfile.TemporaryDirectory() as tmp_dir:
+            script_file = os.path.join(tmp_dir, "script.yaml")
+            with open(script_file, "w", encoding="utf-8") as fos:
+                fos.write("a: {as: x}\nb: {as: x}\n")
+            ret, out, _err = _run_main("--dry-run", "--max-problems", "1", script_file)
+            self.assertEqual(2, ret)
+            self.assertIn("... and 1 more", out)
+
+
+def _run_main(*args: str) -> Tuple[int, str, str]:
+    out = io.StringIO()
+    err = io.StringIO()
+    with contextlib.redirect_stdout(out), contextlib.redirect_stderr(err):
+        ret = main.cli_main(["cli-main", *args])
+    return ret, out.getvalue(), err.getvalue()
+
+
+SCRIPT_1 = """
+name: test-echo
+main:
+  as: core.echo
+  with:
+    text:
+      as-list: string
+      items:
+        - Hello
+    stdout:
+      as: boolean
+      value: true
+"""
+
+SCRIPT_1_JSON = """
+{
+  "name": "test-echo",
+  "main": {
+    "as": "core.echo",
+    "with": {
+      "text": {"as-list": "string", "items": ["Hello"]},
+      "stdout": {"as": "boolean", "value": true}
+    }
+  }
+}
+"""
+
+SCRIPT_1_TOML = """
+name = "test-echo"
+
+[main]
+as = "core.echo"
+
+[main.with.text]
+as-list = "string"
+items = ["Hello"]
+
+[main.with.stdout]
+as = "boolean"
+value = true
+"""
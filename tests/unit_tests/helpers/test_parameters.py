@@ -0,0 +1,43 @@
+"""Test the module."""
+
+import unittest
+from native_shell.helpers import parameters
+from native_shell.defs.basic import mk_ref
+from native_shell.defs.node_type import ConstructType
+from native_shell.defs.syntax_tree import SyntaxNode
+from native_shell.util.message import i18n
+
+
+class ParametersTest(unittest.TestCase):
+    """Test the parameter functions."""
+
+    def test_get_parameter(self) -> None:
+        """Test nested parameter lookups."""
+        headers = SyntaxNode(
+            source=("test", "request", "headers"),
+            node_id=mk_ref(("request", "headers")),
+            node_type=_TYPE,
+            values={"accept": "text/plain"},
+        )
+        request = SyntaxNode(
+            source=("test", "request"),
+            node_id=mk_ref(("request",)),
+            node_type=_TYPE,
+            values={"headers": headers, "retries": 2},
+        )
+        self.assertEqual("text/plain", parameters.get_parameter(request, "headers.accept"))
+        self.assertEqual("text/plain", parameters.get_parameter(request, ("headers", "accept")))
+        self.assertIs(headers, parameters.get_parameter(request, "headers"))
+        self.assertEqual(2, parameters.get_parameter(request, "retries"))
+        self.assertIsNone(parameters.get_parameter(request, "headers.missing"))
+        self.assertIsNone(parameters.get_parameter(request, "retries.count"))
+
+
+_TYPE = ConstructType(
+    source=("test",),
+    type_id="test",
+    title=i18n("test"),
+    description=i18n("test"),
+    parameters=(),
+    fields=(),
+)
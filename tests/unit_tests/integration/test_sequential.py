@@ -0,0 +1,179 @@
+"""Integration test for the sequential run failure policy."""
+
+import unittest
+import datetime
+from native_shell.addin_loader import load_add_ins
+from native_shell.astgen import generate_prepared_script
+from native_shell.codegen import assemble_code, AssembledCode
+from native_shell.defs.script import ScriptSource
+from native_shell.script_parser.v1 import parse_v1
+from native_shell.util.result import Result
+
+
+class SequentialIntegrationTest(unittest.TestCase):
+    """Test the core.run failure policy and finally commands."""
+
+    def test_require_all_success(self) -> None:
+        """Test that later commands are guarded and finally commands always run."""
+
+        res = _generate(SCRIPT_1)
+        self.assertEqual(
+            [],
+            [repr(p) for p in res.problems],
+        )
+        main_go = res.required().main_go
+        self.assertIn("if MainRun0Err == nil {", main_go)
+        self.assertNotIn("if MainRun1Err == nil {", main_go)
+        self.assertLess(
+            main_go.index("if MainRun0Err == nil {"),
+            main_go.index('"two"'),
+        )
+        self.assertIn("if MainErr == nil {\nMainErr = MainFinally0Err\n}", main_go)
+        self.assertLess(main_go.index('"two"'), main_go.index('"cleanup"'))
+
+    def test_step_policies(self) -> None:
+        """Test that each step's failure policy decides whether the sequence continues."""
+        res = _generate(SCRIPT_STEPS)
+        self.assertEqual(
+            [],
+            [repr(p) for p in res.problems],
+        )
+        main_go = res.required().main_go
+        # 'continue' and 'fallback' steps don't guard what follows; 'abort' does.
+        self.assertNotIn("if MainRun0Err == nil {", main_go)
+        self.assertNotIn("if MainRun1Err == nil {", main_go)
+        self.assertIn("if MainRun2Err == nil {", main_go)
+        self.assertLess(main_go.index("if MainRun2Err == nil {"), main_go.index('"four"'))
+        # The fallback only runs if the command failed, and its error becomes the step's.
+        self.assertIn("if MainRun1Err != nil {", main_go)
+        self.assertIn("MainRun1Err = MainRun1FallbackErr", main_go)
+        self.assertLess(
+            main_go.index("if MainRun1Err != nil {"),
+            main_go.index('"two-fallback"'),
+        )
+
+    def test_step_policies__invalid(self) -> None:
+        """Test the problems for unknown policies and a fallback policy without a command."""
+        res = _generate(SCRIPT_BAD_STEPS)
+        self.assertEqual(
+            [
+                "[ERROR] test-seq.yaml/main/run/0 - on failure must be one of abort, continue, "
+                "fallback, found 'retry'",
+                "[ERROR] test-seq.yaml/main/run/1 - on failure 'fallback' requires a fallback "
+                "command",
+            ],
+            sorted(repr(p) for p in res.problems),
+        )
+
+
+def _generate(script: bytes) -> Result[AssembledCode]:
+    return (
+        parse_v1(
+            (
+                (
+                    ScriptSource(
+                        source=("test-seq.yaml",),
+                        src_hash="???",
+                        when=datetime.datetime.now(),
+                    ),
+                    script,
+                ),
+            )
+        )
+        .map_result(load_add_ins)
+        .map_result(lambda script: generate_prepared_script(script, 10))
+        .map_result(assemble_code)
+    )
+
+
+SCRIPT_1 = b"""
+name: test-seq
+version: 1
+
+main:
+  as: core.run
+  with:
+    require all success:
+      as: boolean
+      value: true
+    run:
+      with-list:
+        - as: core.echo
+          with:
+            text: {as-list: string, items: [one]}
+            stdout: {as: boolean, value: true}
+        - as: core.echo
+          with:
+            text: {as-list: string, items: [two]}
+            stdout: {as: boolean, value: true}
+    finally:
+      with-list:
+        - as: core.echo
+          with:
+            text: {as-list: string, items: [cleanup]}
+            stderr: {as: boolean, value: true}
+"""
+
+SCRIPT_STEPS = b"""
+main:
+  as: core.run
+  with:
+    run:
+      with-list:
+        - as: core.step
+          with:
+            on failure: {as: string, value: continue}
+            command:
+              as: core.echo
+              with:
+                text: {as-list: string, items: [one]}
+                stdout: {as: boolean, value: true}
+        - as: core.step
+          with:
+            command:
+              as: core.echo
+              with:
+                text: {as-list: string, items: [two]}
+                stdout: {as: boolean, value: true}
+            fallback:
+              as: core.echo
+              with:
+                text: {as-list: string, items: [two-fallback]}
+                stdout: {as: boolean, value: true}
+        - as: core.step
+          with:
+            on failure: {as: string, value: abort}
+            command:
+              as: core.echo
+              with:
+                text: {as-list: string, items: [three]}
+                stdout: {as: boolean, value: true}
+        - as: core.echo
+          with:
+            text: {as-list: string, items: [four]}
+            stdout: {as: boolean, value: true}
+"""
+
+SCRIPT_BAD_STEPS = b"""
+main:
+  as: core.run
+  with:
+    run:
+      with-list:
+        - as: core.step
+          with:
+            on failure: {as: string, value: retry}
+            command:
+              as: core.echo
+              with:
+                text: {as-list: string, items: [one]}
+                stdout: {as: boolean, value: true}
+        - as: core.step
+          with:
+            on failure: {as: string, value: fallback}
+            command:
+              as: core.echo
+              with:
+                text: {as-list: string, items: [two]}
+                stdout: {as: boolean, value: true}
+"""
@@ -37,6 +37,35 @@ class V1ScriptIntegrationTest(unittest.TestCase):
             [repr(p) for p in res.problems],
         )
 
+    def test_misspelled(self) -> None:
+        """Test that misspelled types and parameters suggest the intended name."""
+
+        res = (
+            parse_v1(
+                (
+                    (
+                        ScriptSource(
+                            source=("test-v1.yaml",),
+                            src_hash="???",
+                            when=datetime.datetime.now(),
+                        ),
+                        SCRIPT_MISSPELLED,
+                    ),
+                )
+            )
+            .map_result(load_add_ins)
+            .map_result(lambda script: generate_prepared_script(script, 10))
+        )
+        self.assertEqual(
+            [
+                "[ERROR] test-v1.yaml/main - node has unknown type core.ecoh; "
+                "did you mean 'core.echo'?",
+                "[ERROR] test-v1.yaml/other/stdot - Node in unknown parent parameter stdot; "
+                "did you mean 'stdout'?",
+            ],
+            sorted(repr(p) for p in res.problems),
+        )
+
 
 SCRIPT_1 = b"""
 
@@ -59,3 +88,15 @@ main:
       value: true
 
 """
+
+SCRIPT_MISSPELLED = b"""
+main:
+  as: core.ecoh
+  with:
+    text: {as-list: string, items: [Hello]}
+other:
+  as: core.echo
+  with:
+    text: {as-list: string, items: [Hello]}
+    stdot: {as: boolean, value: true}
+"""
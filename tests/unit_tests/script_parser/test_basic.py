@@ -0,0 +1,105 @@
+"""Test the module."""
+
+import unittest
+from native_shell.script_parser.v1 import basic
+from native_shell.defs.basic import mk_ref
+from native_shell.defs.node_type import DURATION_TYPE, BYTE_SIZE_TYPE
+from native_shell.defs.parse_tree import ParsedNodeId
+
+
+class BasicTest(unittest.TestCase):
+    """Test the basic type parsing."""
+
+    def test_parse_duration(self) -> None:
+        """Test duration conversions."""
+        self.assertEqual(5_000_000_000, basic.parse_duration("5s"))
+        self.assertEqual(250_000_000, basic.parse_duration("250ms"))
+        self.assertEqual(5_400_000_000_000, basic.parse_duration("1h30m"))
+        self.assertEqual(1_500_000_000, basic.parse_duration("1.5s"))
+        self.assertEqual(-2_000, basic.parse_duration("-2us"))
+        self.assertEqual(0, basic.parse_duration("0"))
+        self.assertEqual(3_000_000_000, basic.parse_duration(3))
+        self.assertEqual(500_000_000, basic.parse_duration(0.5))
+        self.assertIsNone(basic.parse_duration("5"))
+        self.assertIsNone(basic.parse_duration("5 s"))
+        self.assertIsNone(basic.parse_duration("5d"))
+        self.assertIsNone(basic.parse_duration(""))
+        self.assertIsNone(basic.parse_duration(True))
+        self.assertIsNone(basic.parse_duration(["5s"]))
+
+    def test_parse_byte_size(self) -> None:
+        """Test byte size conversions."""
+        self.assertEqual(512, basic.parse_byte_size(512))
+        self.assertEqual(512, basic.parse_byte_size("512"))
+        self.assertEqual(512, basic.parse_byte_size("512B"))
+        self.assertEqual(65_536, basic.parse_byte_size("64KiB"))
+        self.assertEqual(64_000, basic.parse_byte_size("64KB"))
+        self.assertEqual(1_000_000, basic.parse_byte_size("1 mb"))
+        self.assertEqual(1_610_612_736, basic.parse_byte_size("1.5GiB"))
+        self.assertIsNone(basic.parse_byte_size(-1))
+        self.assertIsNone(basic.parse_byte_size("1.5XB"))
+        self.assertIsNone(basic.parse_byte_size("KiB"))
+        self.assertIsNone(basic.parse_byte_size(False))
+        self.assertIsNone(basic.parse_byte_size(1.5))
+        # Only whole numbers of bytes.
+        self.assertEqual(1536, basic.parse_byte_size("1.5KiB"))
+        self.assertEqual(100, basic.parse_byte_size("0.1KB"))
+        self.assertIsNone(basic.parse_byte_size("1.5B"))
+        self.assertIsNone(basic.parse_byte_size("1.5"))
+        self.assertIsNone(basic.parse_byte_size("0.5"))
+        self.assertIsNone(basic.parse_byte_size("0.0001KB"))
+
+    def test_parse_duration__out_of_range(self) -> None:
+        """Test that values which can't be a Go duration are rejected, not raised."""
+        self.assertIsNone(basic.parse_duration(float("inf")))
+        self.assertIsNone(basic.parse_duration(float("-inf")))
+        self.assertIsNone(basic.parse_duration(float("nan")))
+        self.assertIsNone(basic.parse_duration("9" * 400 + "s"))
+        self.assertIsNone(basic.parse_duration(10**10))
+        self.assertEqual(9_000_000_000_000_000_000, basic.parse_duration(9_000_000_000))
+
+    def test_parse_byte_size__out_of_range(self) -> None:
+        """Test that values which can't be a Go int64 are rejected, not raised."""
+        self.assertIsNone(basic.parse_byte_size("9" * 400))
+        self.assertIsNone(basic.parse_byte_size("9" * 400 + "KiB"))
+        self.assertIsNone(basic.parse_byte_size(2**63))
+        self.assertEqual(2**63 - 1, basic.parse_byte_size(2**63 - 1))
+
+    def test_parse_basic_type__duration(self) -> None:
+        """Test parsing a duration value."""
+        res = basic.parse_basic_type(_PARENT, "timeout", DURATION_TYPE, "10ms")
+        self.assertEqual([], [repr(p) for p in res.problems])
+        self.assertEqual(10_000_000, res.required())
+
+        res = basic.parse_basic_type(_PARENT, "timeout", DURATION_TYPE, "soon")
+        self.assertTrue(res.is_not_valid)
+        self.assertEqual(
+            [
+                "[ERROR] test/timeout - node with type duration must be a duration like '5s', "
+                "found 'soon'"
+            ],
+            [repr(p) for p in res.problems],
+        )
+
+    def test_parse_basic_type__byte_size(self) -> None:
+        """Test parsing a byte size value."""
+        res = basic.parse_basic_type(_PARENT, "buffer", BYTE_SIZE_TYPE, "2KiB")
+        self.assertEqual([], [repr(p) for p in res.problems])
+        self.assertEqual(2048, res.required())
+
+        res = basic.parse_basic_type(_PARENT, "buffer", BYTE_SIZE_TYPE, "lots")
+        self.assertTrue(res.is_not_valid)
+
+        res = basic.parse_basic_type(_PARENT, "buffer", BYTE_SIZE_TYPE, "9" * 400)
+        self.assertTrue(res.is_not_valid)
+        self.assertEqual(1, len(res.problems))
+
+    def test_parse_basic_type__duration_not_finite(self) -> None:
+        """Test that infinite and not-a-number durations are problems."""
+        for value in (float("inf"), float("nan")):
+            res = basic.parse_basic_type(_PARENT, "timeout", DURATION_TYPE, value)
+            self.assertTrue(res.is_not_valid)
+            self.assertEqual(1, len(res.problems))
+
+
+_PARENT = ParsedNodeId(source=("test",), ref=mk_ref(()))
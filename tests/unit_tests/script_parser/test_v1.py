@@ -3,6 +3,7 @@
 import unittest
 import datetime
 from native_shell.script_parser import v1
+from native_shell.defs.parse_tree import ParsedSimpleNode, ParsedListNode, ParsedParameterNode
 from native_shell.defs.script import ScriptSource
 
 
@@ -16,6 +17,7 @@ class TestV1(unittest.TestCase):
         script = res.required()
         self.assertEqual("test", script.name)
         self.assertEqual("1", script.version)
+        self.assertEqual(1, script.schema_version)
         self.assertEqual(("core",), script.add_in_names)
 
     def test_simple_2(self) -> None:
@@ -34,6 +36,178 @@ class TestV1(unittest.TestCase):
         self.assertEqual("3", script.version)
         self.assertEqual(("core", "a", "b"), script.add_in_names)
 
+    def test_falsy_values(self) -> None:
+        """Test that zero and false basic values are kept."""
+        res = v1.parse_v1(
+            (
+                (
+                    _mk_ss(),
+                    b"main:\n  as: x\n  with:\n"
+                    b"    wait: {as: duration, value: 0s}\n"
+                    b"    size: {as: byte-size, value: 0}\n"
+                    b"    flag: {as: boolean, value: false}\n",
+                ),
+            )
+        )
+        self.assertEqual([], [repr(p) for p in res.problems])
+        main = res.required().tree.mapping()["main"]
+        values = {}
+        for key, node in main.mapping().items():
+            assert isinstance(node, ParsedSimpleNode)  # nosec  # for typing
+            values[key] = node.value
+        self.assertEqual({"wait": 0, "size": 0, "flag": False}, values)
+
+    def test_schema_version(self) -> None:
+        """Test that the supported schema version is accepted."""
+        res = v1.parse_v1(((_mk_ss(), b"main: {}\nschema-version: 1"),))
+        self.assertEqual([], [repr(p) for p in res.problems])
+        self.assertEqual(1, res.required().schema_version)
+
+    def test_schema_version__newer(self) -> None:
+        """Test that a script written for a newer format is rejected."""
+        res = v1.parse_v1(((_mk_ss(), b"main: {}\nschema-version: 2"),))
+        self.assertTrue(res.is_not_valid)
+        self.assertEqual(
+            [("test", "schema-version")],
+            [tuple(p.source) for p in res.problems],
+        )
+
+    def test_schema_version__invalid(self) -> None:
+        """Test that a non-integer schema version is rejected."""
+        res = v1.parse_v1(((_mk_ss(), b"main: {}\nschema-version: '1.0'"),))
+        self.assertTrue(res.is_not_valid)
+        self.assertEqual(1, len(res.problems))
+
+    def test_anchors(self) -> None:
+        """Test that a YAML anchor can be used more than once."""
+        res = v1.parse_v1(
+            (
+                (
+                    _mk_ss(),
+                    b"first: &flag {as: boolean, value: true}\n"
+                    b"second: *flag\n"
+                    b"third:\n  <<: *flag\n  value: false\n",
+                ),
+            )
+        )
+        self.assertEqual([], [repr(p) for p in res.problems])
+        values = {}
+        for key, node in res.required().tree.mapping().items():
+            assert isinstance(node, ParsedSimpleNode)  # nosec  # for typing
+            values[key] = (node.value, tuple(node.node_id.source))
+        self.assertEqual(
+            {
+                "first": (True, ("test", "first")),
+                "second": (True, ("test", "second")),
+                "third": (False, ("test", "third")),
+            },
+            values,
+        )
+
+    def test_anchors__list_items(self) -> None:
+        """Test that a YAML anchor can be used more than once in a list's items."""
+        res = v1.parse_v1(
+            (
+                (
+                    _mk_ss(),
+                    b"main:\n"
+                    b"  as-list: x\n"
+                    b"  items:\n"
+                    b"    - &item {with: {flag: {as: boolean, value: true}}}\n"
+                    b"    - *item\n",
+                ),
+            )
+        )
+        self.assertEqual([], [repr(p) for p in res.problems])
+        main = res.required().tree.mapping()["main"]
+        assert isinstance(main, ParsedListNode)  # nosec  # for typing
+        self.assertEqual(2, len(main.values()))
+        for item in main.values():
+            assert isinstance(item, ParsedParameterNode)  # nosec  # for typing
+            self.assertEqual(["flag"], list(item.mapping().keys()))
+
+    def test_unknown_key(self) -> None:
+        """Test that a misspelled key is reported with the likely key."""
+        res = v1.parse_v1(
+            (
+                (
+                    _mk_ss(),
+                    b"main: {as: boolean, vlaue: true, value: false, zzz: 1}\n"
+                    b"other: {as: strng, value: 1}\n",
+                ),
+            )
+        )
+        self.assertEqual(
+            [
+                "[ERROR] test/main/vlaue - unknown key 'vlaue'; did you mean 'value'?",
+                "[ERROR] test/main/zzz - unknown key 'zzz'; this node only allows 'as', 'value'",
+                "[ERROR] test/other - 'strng' is not a basic type, so must have a 'with' "
+                "setting; did you mean 'string'?",
+            ],
+            [repr(p) for p in res.problems],
+        )
+
+    def test_unknown_key__misplaced(self) -> None:
+        """Test keys that are misspelled 'with', or allowed only in other node forms."""
+        res = v1.parse_v1(
+            (
+                (
+                    _mk_ss(),
+                    b"a: {as: x, wiht: {}}\n"
+                    b"b: {as-list: x, items: [{as: x, with: {}}]}\n"
+                    b"c: {as: string, as-list: string, items: [x]}\n",
+                ),
+            )
+        )
+        self.assertEqual(
+            [
+                "[ERROR] test/a/wiht - must have a 'with' setting; "
+                "did you mean 'with' instead of 'wiht'?",
+                "[ERROR] test/b/as - unknown key 'as'; this node only allows 'with'",
+                "[ERROR] test/c - exactly one of 'as' and 'as-list' can be present",
+            ],
+            [repr(p) for p in res.problems],
+        )
+
+    def test_json(self) -> None:
+        """Test reading the same structure from JSON."""
+        res = v1.parse_v1(
+            ((_mk_ss(), b'{"name": "test-json", "main": {"as": "boolean", "value": true}}'),),
+            "json",
+        )
+        self.assertEqual([], [repr(p) for p in res.problems])
+        script = res.required()
+        self.assertEqual("test-json", script.name)
+        main = script.tree.mapping()["main"]
+        assert isinstance(main, ParsedSimpleNode)  # nosec  # for typing
+        self.assertIs(True, main.value)
+
+    def test_toml(self) -> None:
+        """Test reading the same structure from TOML."""
+        res = v1.parse_v1(
+            ((_mk_ss(), b'name = "test-toml"\n[main]\nas = "boolean"\nvalue = true\n'),),
+            "toml",
+        )
+        self.assertEqual([], [repr(p) for p in res.problems])
+        script = res.required()
+        self.assertEqual("test-toml", script.name)
+        main = script.tree.mapping()["main"]
+        assert isinstance(main, ParsedSimpleNode)  # nosec  # for typing
+        self.assertIs(True, main.value)
+
+    def test_unknown_format(self) -> None:
+        """Test asking for a source format that doesn't exist."""
+        res = v1.parse_v1(((_mk_ss(), b"main: {}"),), "xml")
+        self.assertTrue(res.is_not_valid)
+        self.assertEqual(1, len(res.problems))
+
+    def test_detect_source_format(self) -> None:
+        """Test picking the source format from the file name."""
+        self.assertEqual("json", v1.detect_source_format("a/script.JSON"))
+        self.assertEqual("toml", v1.detect_source_format("script.toml"))
+        self.assertEqual("yaml", v1.detect_source_format("a/script.yaml"))
+        self.assertEqual("yaml", v1.detect_source_format("script"))
+
 
 def _mk_ss() -> ScriptSource:
     return ScriptSource(
@@ -0,0 +1,163 @@
+"""Test the module."""
+
+import unittest
+import datetime
+import json
+from native_shell.script_parser import v1
+from native_shell.script_parser.v1 import writer
+from native_shell.defs.script import ScriptSource, InitialScript
+
+
+class WriterTest(unittest.TestCase):
+    """Test the script writer."""
+
+    def test_script_to_data(self) -> None:
+        """Test converting a parsed script into the v1 structure."""
+        data = writer.script_to_data(_parse(SCRIPT_1))
+        self.assertEqual(
+            {
+                "name": "test-writer",
+                "version": "2",
+                "main": {
+                    "as": "core.run",
+                    "with": {
+                        "run": {
+                            "with-list": [
+                                {
+                                    "as": "core.echo",
+                                    "with": {
+                                        "text": {"as-list": "string", "items": ["Hello", "you"]},
+                                        "stdout": {"as": "boolean", "value": True},
+                                    },
+                                },
+                            ],
+                        },
+                        "wait": {"as": "duration", "value": "1500ms"},
+                        "size": {"as": "byte-size", "value": 65536},
+                    },
+                },
+            },
+            data,
+        )
+
+    def test_script_to_data__as_given(self) -> None:
+        """Defaults the parser derived are left out, and lists keep their declared type."""
+        data = writer.script_to_data(_parse(SCRIPT_2))
+        self.assertEqual(
+            {
+                "schema-version": 1,
+                "bin": "out/x",
+                "require-libs": [],
+                "main": {
+                    "as": "x",
+                    "with": {
+                        "none": {"as-list": "integer", "items": []},
+                        "many": {
+                            "as-list": "y",
+                            "items": [{"with": {"on": {"as": "boolean", "value": True}}}],
+                        },
+                    },
+                },
+            },
+            data,
+        )
+        reparsed = _parse(writer.dump_yaml(_parse(SCRIPT_2)).encode("utf-8"))
+        self.assertEqual(data, writer.script_to_data(reparsed))
+
+    def test_dump_json__round_trip(self) -> None:
+        """The JSON form parses back into the same script."""
+        original = _parse(SCRIPT_1)
+        text = writer.dump_json(original)
+        self.assertIsInstance(json.loads(text), dict)
+        reparsed = _parse(text.encode("utf-8"))
+        self.assertEqual(writer.script_to_data(original), writer.script_to_data(reparsed))
+
+    def test_dump_yaml__round_trip(self) -> None:
+        """The YAML form parses back into the same script, in the same order."""
+        original = _parse(SCRIPT_1)
+        text = writer.dump_yaml(original)
+        self.assertTrue(text.startswith("name: test-writer\nversion: '2'\nmain:\n"))
+        self.assertLess(text.index("wait:"), text.index("size:"))
+        reparsed = _parse(text.encode("utf-8"))
+        self.assertEqual(writer.script_to_data(original), writer.script_to_data(reparsed))
+        self.assertEqual(text, writer.dump_yaml(reparsed))
+
+    def test_write_script(self) -> None:
+        """Test writing by format name."""
+        original = _parse(SCRIPT_1)
+        res = writer.write_script(original, "json")
+        self.assertEqual([], [repr(p) for p in res.problems])
+        self.assertEqual(writer.dump_json(original), res.required())
+        self.assertEqual(
+            writer.dump_yaml(original),
+            writer.write_script(original, "yaml").required(),
+        )
+
+        res = writer.write_script(original, "xml")
+        self.assertTrue(res.is_not_valid)
+        self.assertEqual(1, len(res.problems))
+
+    def test_format_duration(self) -> None:
+        """Test the duration formatting."""
+        self.assertEqual("0s", writer.format_duration(0))
+        self.assertEqual("2h", writer.format_duration(7_200_000_000_000))
+        self.assertEqual("90m", writer.format_duration(5_400_000_000_000))
+        self.assertEqual("-5s", writer.format_duration(-5_000_000_000))
+        self.assertEqual("250ms", writer.format_duration(250_000_000))
+        self.assertEqual("3us", writer.format_duration(3_000))
+        self.assertEqual("7ns", writer.format_duration(7))
+
+
+def _parse(contents: bytes) -> InitialScript:
+    res = v1.parse_v1(
+        (
+            (
+                ScriptSource(source=("test",), src_hash="???", when=datetime.datetime.now()),
+                contents,
+            ),
+        )
+    )
+    assert res.problems == ()  # nosec  # for typing
+    return res.required()
+
+
+SCRIPT_1 = b"""
+name: test-writer
+version: 2
+main:
+  as: core.run
+  with:
+    run:
+      with-list:
+        - as: core.echo
+          with:
+            text:
+              as-list: string
+              items: [Hello, you]
+            stdout:
+              as: boolean
+              value: true
+    wait:
+      as: duration
+      value: 1.5s
+    size:
+      as: byte-size
+      value: 64KiB
+"""
+
+SCRIPT_2 = b"""
+schema-version: 1
+bin: out/x
+require-libs: []
+main:
+  as: x
+  with:
+    none:
+      as-list: integer
+      items: []
+    many:
+      as-list: y
+      items:
+        - with:
+            "on": {as: boolean, value: true}
+"""
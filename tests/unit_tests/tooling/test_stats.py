@@ -0,0 +1,92 @@
+"""Test the module."""
+
+import unittest
+import datetime
+from native_shell.addin_loader import load_add_ins
+from native_shell.astgen import generate_prepared_script
+from native_shell.codegen import assemble_code
+from native_shell.defs.script import ScriptSource, PreparedScript
+from native_shell.script_parser.v1 import parse_v1
+from native_shell.tooling import stats
+
+
+class StatsTest(unittest.TestCase):
+    """Test the statistics functions."""
+
+    def test_compute_stats(self) -> None:
+        """Test computing the statistics for a sequence of echos."""
+        script = _mk_prepared()
+        code = assemble_code(script).required()
+        found = stats.compute_stats(script, code)
+        # The run, its list of echos, and each echo with its text list.
+        self.assertEqual(6, found.node_count)
+        self.assertEqual(4, found.max_depth)
+        self.assertEqual(2, found.max_fan_out)
+        self.assertEqual({"core.run": 1, "core.echo": 2, "list(any)": 3}, found.type_counts)
+        self.assertEqual({"main": 6}, found.section_counts)
+        # Only the types written in the section; no construct field types.
+        self.assertEqual(
+            {"main": {"core.run": 1, "core.echo": 2, "list(any)": 3}}, found.section_type_counts
+        )
+        self.assertEqual(
+            len(code.makefile) + len(code.go_mod) + len(code.main_go),
+            found.generated_size,
+        )
+
+    def test_format_stats(self) -> None:
+        """Test the report lines."""
+        found = stats.compute_stats(_mk_prepared())
+        lines = stats.format_stats(found)
+        self.assertEqual("nodes: 6", lines[0])
+        self.assertEqual("distinct types: 3", lines[1])
+        self.assertNotIn("generated code size", "\n".join(lines))
+        self.assertIn("  core.echo: 2", lines)
+        self.assertLess(lines.index("  core.echo: 2"), lines.index("  core.run: 1"))
+        section_lines = list(lines[lines.index("nodes by section:") + 1 :])
+        # Each section's types are listed most used first.
+        self.assertEqual(
+            ["  main: 6", "    list(any): 3", "    core.echo: 2", "    core.run: 1"], section_lines
+        )
+
+
+def _mk_prepared() -> PreparedScript:
+    return (
+        parse_v1(
+            (
+                (
+                    ScriptSource(source=("test",), src_hash="???", when=datetime.datetime.now()),
+                    SCRIPT_1,
+                ),
+            )
+        )
+        .map_result(load_add_ins)
+        .map_result(lambda script: generate_prepared_script(script, 10))
+        .required()
+    )
+
+
+SCRIPT_1 = b"""
+main:
+  as: core.run
+  with:
+    run:
+      with-list:
+        - as: core.echo
+          with:
+            text:
+              as-list: string
+              items:
+                - Hello, you!
+            stdout:
+              as: boolean
+              value: true
+        - as: core.echo
+          with:
+            text:
+              as-list: string
+              items:
+                - Hello, me!
+            stdout:
+              as: boolean
+              value: true
+"""
@@ -0,0 +1,45 @@
+"""Test the module."""
+
+import unittest
+import datetime
+from native_shell.addin_loader import load_add_ins
+from native_shell.astgen import generate_prepared_script
+from native_shell.defs.script import ScriptSource
+from native_shell.script_parser.v1 import parse_v1
+from native_shell.tooling import usage
+
+
+class UsageTest(unittest.TestCase):
+    """Test the type usage functions."""
+
+    def test_used_construct_types(self) -> None:
+        """Only the types written in the script are listed."""
+        script = (
+            parse_v1(
+                (
+                    (
+                        ScriptSource(
+                            source=("test",), src_hash="???", when=datetime.datetime.now()
+                        ),
+                        SCRIPT_1,
+                    ),
+                )
+            )
+            .map_result(load_add_ins)
+            .map_result(lambda script: generate_prepared_script(script, 10))
+            .required()
+        )
+        self.assertEqual(["core.echo", "core.run"], usage.used_construct_types(script))
+
+
+SCRIPT_1 = b"""
+main:
+  as: core.run
+  with:
+    run:
+      with-list:
+        - as: core.echo
+          with:
+            text: {as-list: string, items: [Hello]}
+            stdout: {as: boolean, value: true}
+"""
@@ -0,0 +1,34 @@
+"""Test the module."""
+
+import unittest
+from native_shell.util import perf
+
+
+class PhaseTimerTest(unittest.TestCase):
+    """Test the phase timer."""
+
+    def test_disabled(self) -> None:
+        """A disabled timer runs the phases without reporting."""
+        timer = perf.PhaseTimer(False)
+        self.assertEqual(3, timer.run("a", lambda: 3))
+        self.assertEqual(4, timer.wrap("b", lambda x: x + 1)(3))
+        self.assertEqual([], timer.report())
+
+    def test_enabled(self) -> None:
+        """An enabled timer reports each phase in order."""
+        timer = perf.PhaseTimer(True)
+        self.assertEqual(3, timer.run("a", lambda: 3))
+        self.assertEqual(4, timer.wrap("b", lambda x: x + 1)(3))
+        with self.assertRaises(ValueError):
+            timer.run("c", _raise)
+        lines = timer.report()
+        self.assertEqual(5, len(lines))
+        self.assertTrue(lines[0].startswith("phase a: "))
+        self.assertTrue(lines[1].startswith("phase b: "))
+        self.assertTrue(lines[2].startswith("phase c: "))
+        self.assertTrue(lines[3].startswith("total: "))
+        self.assertTrue(lines[4].startswith("peak memory: "))
+
+
+def _raise() -> None:
+    raise ValueError("failed")
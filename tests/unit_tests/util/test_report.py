@@ -0,0 +1,65 @@
+"""Test the module."""
+
+import unittest
+from native_shell.util import report
+from native_shell.util.message import UserMessage, i18n
+from native_shell.util.result import Problem, ProblemLevel, SourcePath
+
+
+class ReportTest(unittest.TestCase):
+    """Test the report functions."""
+
+    def test_format_problems__empty(self) -> None:
+        """No problems means no report."""
+        self.assertEqual([], report.format_problems(()))
+
+    def test_format_problems__grouped(self) -> None:
+        """Problems are grouped by file and node, and sorted by source."""
+        problems = (
+            _mk(("b.yaml", "main"), "warning", "b main warning"),
+            _mk(("a.yaml", "main", "text", 10), "error", "a text 10"),
+            _mk(("a.yaml", "main", "text", 2), "error", "a text 2"),
+            _mk(("a.yaml", "main"), "warning", "a main warning"),
+            _mk(("a.yaml", "main"), "error", "a main error"),
+            _mk(("a.yaml",), "info", "a file info"),
+        )
+        self.assertEqual(
+            [
+                "a.yaml:",
+                "  [INFO] a file info",
+                "  main:",
+                "    [ERROR] a main error",
+                "    [WARNING] a main warning",
+                "  main/text/2:",
+                "    [ERROR] a text 2",
+                "  main/text/10:",
+                "    [ERROR] a text 10",
+                "b.yaml:",
+                "  main:",
+                "    [WARNING] b main warning",
+                "3 error(s), 2 warning(s), 1 info",
+            ],
+            report.format_problems(problems),
+        )
+
+    def test_format_problems__max_count(self) -> None:
+        """Only the first problems are listed, but all are counted."""
+        problems = (
+            _mk(("a.yaml", "x"), "error", "one"),
+            _mk(("a.yaml", "y"), "error", "two"),
+            _mk(("a.yaml", "z"), "warning", "three"),
+        )
+        self.assertEqual(
+            [
+                "a.yaml:",
+                "  x:",
+                "    [ERROR] one",
+                "... and 2 more",
+                "2 error(s), 1 warning(s), 0 info",
+            ],
+            report.format_problems(problems, 1),
+        )
+
+
+def _mk(source: SourcePath, level: ProblemLevel, text: str) -> Problem:
+    return Problem(source=source, level=level, message=UserMessage(i18n(text)))
@@ -0,0 +1,15 @@
+"""Test the module."""
+
+import unittest
+from native_shell.util import suggest
+
+
+class SuggestTest(unittest.TestCase):
+    """Test the suggestion functions."""
+
+    def test_closest_match(self) -> None:
+        """Test finding the intended name."""
+        self.assertEqual("value", suggest.closest_match("vlaue", ("as", "value")))
+        self.assertEqual("core.echo", suggest.closest_match("core.ecoh", ("core.run", "core.echo")))
+        self.assertIsNone(suggest.closest_match("zzz", ("as", "value")))
+        self.assertIsNone(suggest.closest_match("value", ()))